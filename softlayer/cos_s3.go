@@ -0,0 +1,369 @@
+package softlayer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Cloud Object Storage accepts any signing region, the location of a bucket is set by its location constraint
+	cosSigningRegion = "us-east-1"
+
+	s3TimeFormat = "20060102T150405Z"
+	s3DateFormat = "20060102"
+)
+
+// s3Error is returned by cosClient when the S3 API answers with an unexpected status
+type s3Error struct {
+	StatusCode int
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+	Method     string
+	Path       string
+}
+
+func (e s3Error) Error() string {
+	return fmt.Sprintf("S3 %s %s: %d %s %s", e.Method, e.Path, e.StatusCode, e.Code, e.Message)
+}
+
+func isS3NotFound(err error) bool {
+	apiErr, ok := err.(s3Error)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// cosClient is a minimal client of the S3 API of Cloud Object Storage, using path-style requests
+// signed with AWS Signature Version 4.
+type cosClient struct {
+	endpoint  string
+	accessKey string
+	secretKey string
+
+	httpClient *http.Client
+}
+
+func newCosClient(endpoint, accessKey, secretKey string) *cosClient {
+	return &cosClient{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// do sends a signed request for a bucket and returns the response body
+func (c *cosClient) do(method, bucket string, query url.Values, headers http.Header, body []byte) (
+	http.Header, []byte, error) {
+
+	path := "/" + bucket
+	target := c.endpoint + s3EscapePath(path)
+	if len(query) > 0 {
+		target += "?" + s3CanonicalQuery(query)
+	}
+
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+
+	payloadHash := sha256.Sum256(body)
+	signS3Request(req, hex.EncodeToString(payloadHash[:]), c.accessKey, c.secretKey, cosSigningRegion, time.Now())
+
+	log.Printf("[DEBUG] S3 request: %s %s", method, req.URL.RequestURI())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := s3Error{}
+		xml.Unmarshal(respBody, &apiErr)
+		apiErr.StatusCode = resp.StatusCode
+		apiErr.Method = method
+		apiErr.Path = req.URL.RequestURI()
+		return nil, nil, apiErr
+	}
+
+	return resp.Header, respBody, nil
+}
+
+type s3CreateBucketConfiguration struct {
+	XMLName            xml.Name `xml:"CreateBucketConfiguration"`
+	LocationConstraint string   `xml:"LocationConstraint"`
+}
+
+type s3VersioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+type s3LifecycleConfiguration struct {
+	XMLName xml.Name          `xml:"LifecycleConfiguration"`
+	Rules   []s3LifecycleRule `xml:"Rule"`
+}
+
+type s3LifecycleRule struct {
+	ID                          string                           `xml:"ID"`
+	Prefix                      string                           `xml:"Prefix"`
+	Status                      string                           `xml:"Status"`
+	Transition                  *s3LifecycleTransition           `xml:"Transition,omitempty"`
+	Expiration                  *s3LifecycleExpiration           `xml:"Expiration,omitempty"`
+	NoncurrentVersionExpiration *s3LifecycleNoncurrentExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
+}
+
+type s3LifecycleTransition struct {
+	Days         int    `xml:"Days"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type s3LifecycleExpiration struct {
+	Days int `xml:"Days"`
+}
+
+type s3LifecycleNoncurrentExpiration struct {
+	NoncurrentDays int `xml:"NoncurrentDays"`
+}
+
+func (c *cosClient) createBucket(bucket, locationConstraint string) error {
+	var body []byte
+	if locationConstraint != "" {
+		var err error
+		body, err = xml.Marshal(s3CreateBucketConfiguration{LocationConstraint: locationConstraint})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, err := c.do("PUT", bucket, nil, nil, body)
+	return err
+}
+
+func (c *cosClient) headBucket(bucket string) error {
+	_, _, err := c.do("HEAD", bucket, nil, nil, nil)
+	return err
+}
+
+func (c *cosClient) deleteBucket(bucket string) error {
+	_, _, err := c.do("DELETE", bucket, nil, nil, nil)
+	return err
+}
+
+func (c *cosClient) getBucketLocation(bucket string) (string, error) {
+	_, body, err := c.do("GET", bucket, url.Values{"location": {""}}, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var location struct {
+		LocationConstraint string `xml:",chardata"`
+	}
+	if err := xml.Unmarshal(body, &location); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(location.LocationConstraint), nil
+}
+
+// getBucketVersioning returns the versioning status of a bucket: Enabled, Suspended or an empty string
+// when versioning was never enabled.
+func (c *cosClient) getBucketVersioning(bucket string) (string, error) {
+	_, body, err := c.do("GET", bucket, url.Values{"versioning": {""}}, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var versioning s3VersioningConfiguration
+	if err := xml.Unmarshal(body, &versioning); err != nil {
+		return "", err
+	}
+
+	return versioning.Status, nil
+}
+
+func (c *cosClient) putBucketVersioning(bucket, status string) error {
+	body, err := xml.Marshal(s3VersioningConfiguration{Status: status})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.do("PUT", bucket, url.Values{"versioning": {""}}, nil, body)
+	return err
+}
+
+// getBucketLifecycle returns the lifecycle rules of a bucket. A bucket without lifecycle configuration
+// has no rules.
+func (c *cosClient) getBucketLifecycle(bucket string) ([]s3LifecycleRule, error) {
+	_, body, err := c.do("GET", bucket, url.Values{"lifecycle": {""}}, nil, nil)
+	if err != nil {
+		if apiErr, ok := err.(s3Error); ok && apiErr.Code == "NoSuchLifecycleConfiguration" {
+			return []s3LifecycleRule{}, nil
+		}
+		return nil, err
+	}
+
+	var lifecycle s3LifecycleConfiguration
+	if err := xml.Unmarshal(body, &lifecycle); err != nil {
+		return nil, err
+	}
+
+	return lifecycle.Rules, nil
+}
+
+// putBucketLifecycle replaces the lifecycle rules of a bucket. The configuration is deleted when there
+// are no rules, since S3 rejects an empty configuration.
+func (c *cosClient) putBucketLifecycle(bucket string, rules []s3LifecycleRule) error {
+	query := url.Values{"lifecycle": {""}}
+
+	if len(rules) == 0 {
+		_, _, err := c.do("DELETE", bucket, query, nil, nil)
+		return err
+	}
+
+	body, err := xml.Marshal(s3LifecycleConfiguration{Rules: rules})
+	if err != nil {
+		return err
+	}
+
+	hash := md5.Sum(body)
+	headers := http.Header{}
+	headers.Set("Content-Md5", base64.StdEncoding.EncodeToString(hash[:]))
+
+	_, _, err = c.do("PUT", bucket, query, headers, body)
+	return err
+}
+
+// signS3Request adds the AWS Signature Version 4 headers to a request. All the headers of the request
+// are signed.
+func signS3Request(req *http.Request, payloadHash, accessKey, secretKey, region string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(s3TimeFormat)
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", t.Format(s3DateFormat), region)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "authorization" || name == "user-agent" {
+			continue
+		}
+		trimmed := make([]string, 0, len(values))
+		for _, value := range values {
+			trimmed = append(trimmed, strings.Join(strings.Fields(value), " "))
+		}
+		headers[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders bytes.Buffer
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.Path
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(path),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := s3HMAC([]byte("AWS4"+secretKey), t.Format(s3DateFormat))
+	signingKey = s3HMAC(signingKey, region)
+	signingKey = s3HMAC(signingKey, "s3")
+	signingKey = s3HMAC(signingKey, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, hex.EncodeToString(s3HMAC(signingKey, stringToSign))))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3CanonicalQuery encodes a query string with sorted keys, as required by Signature Version 4
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(key, true)+"="+s3Escape(value, true))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func s3EscapePath(path string) string {
+	return s3Escape(path, false)
+}
+
+// s3Escape percent-encodes every byte but the unreserved characters of RFC 3986. Slashes are kept
+// unless encodeSlash is set.
+func s3Escape(s string, encodeSlash bool) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			buf.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
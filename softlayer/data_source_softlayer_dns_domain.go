@@ -0,0 +1,68 @@
+package softlayer
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func dataSourceSoftLayerDnsDomain() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerDnsDomainRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"serial": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"nameservers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerDnsDomainRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	found, err := findDnsDomainByName(sess, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	domain, err := services.GetDnsDomainService(sess).Id(*found.Id).Mask(DnsDomainMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Domain %d: %s", *found.Id, err)
+	}
+
+	// The nameservers of the domain are the data of its NS records
+	nameservers := make([]string, 0)
+	for _, record := range domain.ResourceRecords {
+		if sl.Get(record.Type, "") == "ns" && sl.Get(record.Host, "") == "@" {
+			nameservers = append(nameservers, sl.Get(record.Data, "").(string))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d", *domain.Id))
+	d.Set("id", *domain.Id)
+	d.Set("serial", sl.Get(domain.Serial, 0))
+	d.Set("nameservers", nameservers)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerDnsDomainDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerDnsDomainDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.softlayer_dns_domain.domain", "name", "tfacc-domain-data.com"),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_dns_domain.domain", "id", regexp.MustCompile("^[0-9]+$")),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_dns_domain.domain", "serial", regexp.MustCompile("^[0-9]+$")),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_dns_domain.domain", "nameservers.0", regexp.MustCompile("softlayer")),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerDnsDomainDataSourceConfig_basic = `
+resource "softlayer_dns_domain" "domain" {
+    name = "tfacc-domain-data.com"
+    target = "172.16.0.100"
+}
+
+data "softlayer_dns_domain" "domain" {
+    name = "${softlayer_dns_domain.domain.name}"
+}
+`
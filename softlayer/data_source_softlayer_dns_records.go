@@ -0,0 +1,128 @@
+package softlayer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func dataSourceSoftLayerDnsRecords() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerDnsRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"data": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ttls": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"priorities": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerDnsRecordsRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	domainId := d.Get("domain_id").(int)
+
+	domain, err := services.GetDnsDomainService(sess).Id(domainId).Mask(DnsDomainMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Domain %d: %s", domainId, err)
+	}
+
+	records := filterDnsRecords(domain.ResourceRecords, d.Get("host").(string), d.Get("type").(string))
+
+	ids := make([]int, 0, len(records))
+	data := make([]string, 0, len(records))
+	ttls := make([]int, 0, len(records))
+	priorities := make([]int, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, sl.Get(record.Id, 0).(int))
+		data = append(data, sl.Get(record.Data, "").(string))
+		ttls = append(ttls, sl.Get(record.Ttl, 0).(int))
+		priorities = append(priorities, dnsRecordPriority(record))
+	}
+
+	d.SetId(fmt.Sprintf("%d", domainId))
+	d.Set("ids", ids)
+	d.Set("data", data)
+	d.Set("ttls", ttls)
+	d.Set("priorities", priorities)
+
+	return nil
+}
+
+type dnsRecordsById []datatypes.Dns_Domain_ResourceRecord
+
+func (r dnsRecordsById) Len() int      { return len(r) }
+func (r dnsRecordsById) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r dnsRecordsById) Less(i, j int) bool {
+	return sl.Get(r[i].Id, 0).(int) < sl.Get(r[j].Id, 0).(int)
+}
+
+// filterDnsRecords returns the records with the given host and type, ordered by ID. Empty filters match
+// any record.
+func filterDnsRecords(records []datatypes.Dns_Domain_ResourceRecord, host, recordType string) []datatypes.Dns_Domain_ResourceRecord {
+	filtered := make([]datatypes.Dns_Domain_ResourceRecord, 0, len(records))
+	for _, record := range records {
+		if host != "" && !strings.EqualFold(sl.Get(record.Host, "").(string), host) {
+			continue
+		}
+		if recordType != "" && !strings.EqualFold(sl.Get(record.Type, "").(string), recordType) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+
+	sort.Sort(dnsRecordsById(filtered))
+	return filtered
+}
+
+// dnsRecordPriority returns the MX priority of MX records and the priority of SRV records, or 0
+func dnsRecordPriority(record datatypes.Dns_Domain_ResourceRecord) int {
+	switch sl.Get(record.Type, "") {
+	case "mx":
+		return sl.Get(record.MxPriority, 0).(int)
+	case "srv":
+		return sl.Get(record.Priority, 0).(int)
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerDnsRecordsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerDnsRecordsDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.softlayer_dns_records.www", "data.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_dns_records.www", "ttls.0", "900"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_dns_records.mx", "data.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_dns_records.mx", "priorities.0", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterDnsRecords(t *testing.T) {
+	records := []datatypes.Dns_Domain_ResourceRecord{
+		{Id: sl.Int(3), Host: sl.String("www"), Type: sl.String("a"), Data: sl.String("10.0.0.2")},
+		{Id: sl.Int(1), Host: sl.String("www"), Type: sl.String("a"), Data: sl.String("10.0.0.1")},
+		{Id: sl.Int(2), Host: sl.String("WWW"), Type: sl.String("aaaa"), Data: sl.String("fe80::1")},
+		{Id: sl.Int(4), Host: sl.String("@"), Type: sl.String("mx"), Data: sl.String("mail"), MxPriority: sl.Int(10)},
+	}
+
+	filtered := filterDnsRecords(records, "www", "a")
+	if len(filtered) != 2 || *filtered[0].Id != 1 || *filtered[1].Id != 3 {
+		t.Errorf("Unexpected records for www a: %v", filtered)
+	}
+
+	if filtered := filterDnsRecords(records, "www", ""); len(filtered) != 3 {
+		t.Errorf("Expected 3 records for www, got %d", len(filtered))
+	}
+
+	if filtered := filterDnsRecords(records, "", ""); len(filtered) != 4 {
+		t.Errorf("Expected all records without filters, got %d", len(filtered))
+	}
+
+	if priority := dnsRecordPriority(records[3]); priority != 10 {
+		t.Errorf("Expected priority 10, got %d", priority)
+	}
+}
+
+const testAccCheckSoftLayerDnsRecordsDataSourceConfig_basic = `
+resource "softlayer_dns_domain" "domain" {
+    name = "tfacc-records-data.com"
+    target = "172.16.0.100"
+
+    records {
+        host = "www"
+        type = "a"
+        data = "172.16.0.101"
+        ttl = 900
+    }
+
+    records {
+        host = "www"
+        type = "a"
+        data = "172.16.0.102"
+        ttl = 900
+    }
+
+    records {
+        host = "@"
+        type = "mx"
+        data = "www.tfacc-records-data.com."
+        mx_priority = 10
+    }
+}
+
+data "softlayer_dns_records" "www" {
+    domain_id = "${softlayer_dns_domain.domain.id}"
+    host = "www"
+    type = "a"
+}
+
+data "softlayer_dns_records" "mx" {
+    domain_id = "${softlayer_dns_domain.domain.id}"
+    type = "mx"
+}
+`
@@ -0,0 +1,100 @@
+package softlayer
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func dataSourceSoftLayerDnsZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerDnsZoneFileRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"name"},
+			},
+
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"domain_id"},
+			},
+
+			"zone_file": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerDnsZoneFileRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsDomainService(sess)
+
+	domainId, err := getDnsZoneFileDomainId(d, sess)
+	if err != nil {
+		return err
+	}
+
+	domain, err := service.Id(domainId).Mask("id,name").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Domain %d: %s", domainId, err)
+	}
+
+	contents, err := service.Id(domainId).GetZoneFileContents()
+	if err != nil {
+		return fmt.Errorf("Error retrieving the zone file of Dns Domain %d: %s", domainId, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", domainId))
+	d.Set("domain_id", domainId)
+	d.Set("name", sl.Get(domain.Name, ""))
+	d.Set("zone_file", contents)
+
+	return nil
+}
+
+func getDnsZoneFileDomainId(d *schema.ResourceData, sess *session.Session) (int, error) {
+	if domainId, ok := d.GetOk("domain_id"); ok {
+		return domainId.(int), nil
+	}
+
+	name, ok := d.GetOk("name")
+	if !ok {
+		return 0, fmt.Errorf("One of domain_id or name must be set")
+	}
+
+	domain, err := findDnsDomainByName(sess, name.(string))
+	if err != nil {
+		return 0, err
+	}
+
+	return *domain.Id, nil
+}
+
+// findDnsDomainByName looks up a domain of the account by its exact name
+func findDnsDomainByName(sess *session.Session, name string) (datatypes.Dns_Domain, error) {
+	domains, err := services.GetDnsDomainService(sess).Mask("id,name").GetByDomainName(sl.String(name))
+	if err != nil {
+		return datatypes.Dns_Domain{}, fmt.Errorf("Error looking up Dns Domain %s: %s", name, err)
+	}
+
+	// Domains are looked up by partial name, so only an exact match is used
+	for _, domain := range domains {
+		if domain.Name != nil && *domain.Name == name {
+			return domain, nil
+		}
+	}
+
+	return datatypes.Dns_Domain{}, fmt.Errorf("No Dns Domain was found with the name '%s'", name)
+}
@@ -0,0 +1,51 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerDnsZoneFileDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerDnsZoneFileDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.softlayer_dns_zone_file.by_id", "name", "tfacc-zone-file.com"),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_dns_zone_file.by_id", "zone_file",
+						regexp.MustCompile(`www\s+\d+\s+IN\s+A\s+172\.16\.0\.102`)),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_dns_zone_file.by_name", "zone_file",
+						regexp.MustCompile(`SOA`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerDnsZoneFileDataSourceConfig_basic = `
+resource "softlayer_dns_domain" "zone" {
+    name = "tfacc-zone-file.com"
+    target = "172.16.0.100"
+    zone_file = <<EOZ
+$TTL 900
+www     IN  A     172.16.0.102
+mail    IN  MX    10 www
+_sip._tcp IN SRV  10 20 5060 www
+EOZ
+}
+
+data "softlayer_dns_zone_file" "by_id" {
+    domain_id = "${softlayer_dns_domain.zone.id}"
+}
+
+data "softlayer_dns_zone_file" "by_name" {
+    name = "${softlayer_dns_domain.zone.name}"
+}
+`
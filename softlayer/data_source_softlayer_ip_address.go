@@ -0,0 +1,123 @@
+package softlayer
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func dataSourceSoftLayerIpAddress() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerIpAddressRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"ip_address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"note": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_reserved": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"subnet_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"subnet": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"gateway": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"broadcast_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"netmask": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"virtual_guest_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"hardware_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerIpAddressRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkSubnetIpAddressService(sess)
+
+	ipAddress := d.Get("ip_address").(string)
+
+	ip, err := service.
+		Mask("id,ipAddress,note,isReserved,virtualGuest[id],hardware[id]," +
+			"subnet[id,networkIdentifier,cidr,gateway,broadcastAddress,netmask,networkVlanId]").
+		GetByIpAddress(sl.String(ipAddress))
+	if err != nil {
+		return fmt.Errorf("Error looking up ip address: %s", err)
+	}
+
+	if ip.Id == nil {
+		return fmt.Errorf("No ip address was found matching '%s'", ipAddress)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *ip.Id))
+	d.Set("note", sl.Get(ip.Note, ""))
+	d.Set("is_reserved", sl.Get(ip.IsReserved, false))
+
+	if ip.Subnet != nil {
+		d.Set("subnet_id", sl.Get(ip.Subnet.Id, 0))
+		if ip.Subnet.NetworkIdentifier != nil && ip.Subnet.Cidr != nil {
+			d.Set("subnet", fmt.Sprintf("%s/%d", *ip.Subnet.NetworkIdentifier, *ip.Subnet.Cidr))
+		}
+		d.Set("gateway", sl.Get(ip.Subnet.Gateway, ""))
+		d.Set("broadcast_address", sl.Get(ip.Subnet.BroadcastAddress, ""))
+		d.Set("netmask", sl.Get(ip.Subnet.Netmask, ""))
+		d.Set("vlan_id", sl.Get(ip.Subnet.NetworkVlanId, 0))
+	}
+
+	// An ip address is bound to at most one of a virtual guest or a hardware
+	if ip.VirtualGuest != nil {
+		d.Set("virtual_guest_id", sl.Get(ip.VirtualGuest.Id, 0))
+	}
+
+	if ip.Hardware != nil {
+		d.Set("hardware_id", sl.Get(ip.Hardware.Id, 0))
+	}
+
+	return nil
+}
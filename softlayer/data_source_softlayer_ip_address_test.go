@@ -0,0 +1,52 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerIpAddressDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerIpAddressDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerResources("data.softlayer_ip_address.tfacc_ip", "virtual_guest_id",
+						"softlayer_virtual_guest.ipdsvm1", "id"),
+					testAccCheckSoftLayerResources("data.softlayer_ip_address.tfacc_ip", "vlan_id",
+						"softlayer_virtual_guest.ipdsvm1", "public_vlan_id"),
+					testAccCheckSoftLayerResources("data.softlayer_ip_address.tfacc_ip", "subnet",
+						"softlayer_virtual_guest.ipdsvm1", "public_subnet"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_ip_address.tfacc_ip",
+						"hardware_id",
+						"0",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerIpAddressDataSourceConfig_basic = `
+resource "softlayer_virtual_guest" "ipdsvm1" {
+    name = "ipdsvm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+data "softlayer_ip_address" "tfacc_ip" {
+    ip_address = "${softlayer_virtual_guest.ipdsvm1.ipv4_address}"
+}
+`
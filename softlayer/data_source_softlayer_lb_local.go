@@ -0,0 +1,211 @@
+package softlayer
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const LbLocalDataSourceMask = lbMask + ",notes," +
+	"virtualServers[id,port,allocation,serviceGroups[id,timeout,routingMethod[keyname],routingType[keyname]]]"
+
+func dataSourceSoftLayerLbLocal() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerLbLocalRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"connections": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ha_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"dedicated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"ssl_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"ssl_active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"security_certificate_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"subnet_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"virtual_servers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"allocation": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"service_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_group_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"virtual_server_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"routing_method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"routing_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerLbLocalRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	ipAddress, hasIpAddress := d.GetOk("ip_address")
+	name, hasName := d.GetOk("name")
+
+	if hasIpAddress == hasName {
+		return fmt.Errorf("One of 'ip_address' or 'name' must be set")
+	}
+
+	// Local load balancers have no name, the notes of the virtual IP address hold the name seen on the portal
+	field, path, value := "IP address", "adcLoadBalancers.ipAddress.ipAddress", ipAddress.(string)
+	if hasName {
+		field, path, value = "name", "adcLoadBalancers.notes", name.(string)
+	}
+
+	lbs, err := getAdcLoadBalancers(sess, path, value, LbLocalDataSourceMask)
+	if err != nil {
+		return fmt.Errorf("Error looking up local load balancer: %s", err)
+	}
+
+	if len(lbs) == 0 {
+		return fmt.Errorf("No local load balancer was found with the %s '%s'", field, value)
+	}
+
+	if len(lbs) > 1 {
+		return fmt.Errorf("More than one local load balancer was found with the %s '%s'", field, value)
+	}
+
+	lb := lbs[0]
+
+	d.SetId(fmt.Sprintf("%d", *lb.Id))
+	d.Set("name", sl.Get(lb.Notes, ""))
+	d.Set("connections", getConnectionLimit(sl.Get(lb.ConnectionLimit, 0).(int)))
+	d.Set("ha_enabled", sl.Get(lb.HighAvailabilityFlag, false))
+	d.Set("dedicated", sl.Get(lb.DedicatedFlag, false))
+	d.Set("ssl_enabled", sl.Get(lb.SslEnabledFlag, false))
+	d.Set("ssl_active", sl.Get(lb.SslActiveFlag, false))
+	d.Set("security_certificate_id", sl.Get(lb.SecurityCertificateId, 0))
+
+	if lb.IpAddress != nil {
+		d.Set("ip_address", sl.Get(lb.IpAddress.IpAddress, ""))
+		d.Set("subnet_id", sl.Get(lb.IpAddress.SubnetId, 0))
+	}
+
+	if len(lb.LoadBalancerHardware) > 0 && lb.LoadBalancerHardware[0].Datacenter != nil {
+		d.Set("datacenter", sl.Get(lb.LoadBalancerHardware[0].Datacenter.Name, ""))
+	}
+
+	virtualServers := make([]map[string]interface{}, 0, len(lb.VirtualServers))
+	serviceGroups := make([]map[string]interface{}, 0, len(lb.VirtualServers))
+	for _, vs := range lb.VirtualServers {
+		virtualServers = append(virtualServers, map[string]interface{}{
+			"id":         sl.Get(vs.Id, 0),
+			"port":       sl.Get(vs.Port, 0),
+			"allocation": sl.Get(vs.Allocation, 0),
+		})
+
+		for _, sg := range vs.ServiceGroups {
+			serviceGroup := map[string]interface{}{
+				"service_group_id":  sl.Get(sg.Id, 0),
+				"virtual_server_id": sl.Get(vs.Id, 0),
+				"port":              sl.Get(vs.Port, 0),
+				"timeout":           sl.Get(sg.Timeout, 0),
+			}
+			if sg.RoutingMethod != nil {
+				serviceGroup["routing_method"] = sl.Get(sg.RoutingMethod.Keyname, "")
+			}
+			if sg.RoutingType != nil {
+				serviceGroup["routing_type"] = sl.Get(sg.RoutingType.Keyname, "")
+			}
+			serviceGroups = append(serviceGroups, serviceGroup)
+		}
+	}
+	d.Set("virtual_servers", virtualServers)
+	d.Set("service_groups", serviceGroups)
+
+	return nil
+}
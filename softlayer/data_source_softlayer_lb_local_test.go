@@ -0,0 +1,59 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerLbLocalDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerLbLocalDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.softlayer_lb_local.lb", "id", regexp.MustCompile("^[0-9]+$")),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_local.lb", "connections", "250"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_local.lb", "datacenter", "tok02"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_local.lb", "ssl_enabled", "false"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_local.lb", "service_groups.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_local.lb", "service_groups.0.port", "82"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_local.lb", "service_groups.0.routing_method", "CONSISTENT_HASH_IP"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_local.lb", "virtual_servers.0.allocation", "100"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerLbLocalDataSourceConfig_basic = `
+resource "softlayer_lb_local" "testacc_foobar_lb" {
+    connections = 250
+    datacenter    = "tok02"
+    ha_enabled  = false
+}
+
+resource "softlayer_lb_local_service_group" "test_service_group" {
+    port = 82
+    routing_method = "CONSISTENT_HASH_IP"
+    routing_type = "HTTP"
+    load_balancer_id = "${softlayer_lb_local.testacc_foobar_lb.id}"
+    allocation = 100
+}
+
+data "softlayer_lb_local" "lb" {
+    ip_address = "${softlayer_lb_local.testacc_foobar_lb.ip_address}"
+    depends_on = ["softlayer_lb_local_service_group.test_service_group"]
+}
+`
@@ -0,0 +1,187 @@
+package softlayer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const LbVpxDataSourceMask = "id,name,type[name],datacenter[name],managementIpAddress,description," +
+	"networkVlans[id,vlanNumber,primaryRouter[hostname]],subnets[ipAddresses[ipAddress]]"
+
+func dataSourceSoftLayerLbVpx() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerLbVpxRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"management_ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"speed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"plan": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_vlan_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"private_vlan_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"vlans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"vlan_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"router_hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"vip_pool": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetAccountService(sess)
+
+	name, hasName := d.GetOk("name")
+	managementIp, hasManagementIp := d.GetOk("management_ip_address")
+
+	if hasName == hasManagementIp {
+		return fmt.Errorf("One of 'name' or 'management_ip_address' must be set")
+	}
+
+	field, path, value := "name", "applicationDeliveryControllers.name", name.(string)
+	if hasManagementIp {
+		field, path, value = "management IP address", "applicationDeliveryControllers.managementIpAddress",
+			managementIp.(string)
+	}
+
+	vpxs, err := service.
+		Mask(LbVpxDataSourceMask).
+		Filter(filter.Path(path).Eq(value).Build()).
+		GetApplicationDeliveryControllers()
+	if err != nil {
+		return fmt.Errorf("Error looking up Netscaler VPX: %s", err)
+	}
+
+	if len(vpxs) == 0 {
+		return fmt.Errorf("No Netscaler VPX was found with the %s '%s'", field, value)
+	}
+
+	if len(vpxs) > 1 {
+		return fmt.Errorf("More than one Netscaler VPX was found with the %s '%s'", field, value)
+	}
+
+	vpx := vpxs[0]
+
+	d.SetId(fmt.Sprintf("%d", *vpx.Id))
+	d.Set("name", sl.Get(vpx.Name, ""))
+	d.Set("management_ip_address", sl.Get(vpx.ManagementIpAddress, ""))
+
+	if vpx.Type != nil {
+		d.Set("type", sl.Get(vpx.Type.Name, ""))
+	}
+
+	if vpx.Datacenter != nil {
+		d.Set("datacenter", sl.Get(vpx.Datacenter.Name, ""))
+	}
+
+	speed, version, plan := parseVpxDescription(sl.Get(vpx.Description, "").(string))
+	d.Set("speed", speed)
+	d.Set("version", version)
+	d.Set("plan", plan)
+
+	// Like the softlayer_lb_vpx resource, the VLANs are told apart by their frontend or backend router
+	vlans := make([]map[string]interface{}, 0, len(vpx.NetworkVlans))
+	for _, vlan := range vpx.NetworkVlans {
+		routerHostname := ""
+		if vlan.PrimaryRouter != nil {
+			routerHostname = sl.Get(vlan.PrimaryRouter.Hostname, "").(string)
+		}
+
+		if strings.HasPrefix(routerHostname, "fcr") {
+			d.Set("public_vlan_id", sl.Get(vlan.Id, 0))
+		} else if strings.HasPrefix(routerHostname, "bcr") {
+			d.Set("private_vlan_id", sl.Get(vlan.Id, 0))
+		}
+
+		vlans = append(vlans, map[string]interface{}{
+			"id":              sl.Get(vlan.Id, 0),
+			"vlan_number":     sl.Get(vlan.VlanNumber, 0),
+			"router_hostname": routerHostname,
+		})
+	}
+	d.Set("vlans", vlans)
+
+	vips := make([]string, 0)
+	for _, subnet := range vpx.Subnets {
+		for _, ipAddress := range subnet.IpAddresses {
+			vips = append(vips, sl.Get(ipAddress.IpAddress, "").(string))
+		}
+	}
+	d.Set("vip_pool", vips)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerLbVpxDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerLbVpxDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.softlayer_lb_vpx.vpx", "id", regexp.MustCompile("^[0-9]+$")),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_vpx.vpx", "version", "10.1"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_vpx.vpx", "speed", "10"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_vpx.vpx", "plan", "Standard"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_lb_vpx.vpx", "vip_pool.#", "2"),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_lb_vpx.vpx", "public_vlan_id", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_lb_vpx.vpx", "private_vlan_id", regexp.MustCompile("^[1-9][0-9]*$")),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerLbVpxDataSourceConfig_basic = `
+resource "softlayer_lb_vpx" "testacc_foobar_vpx" {
+    datacenter = "dal06"
+    speed = 10
+    version = "10.1"
+    plan = "Standard"
+    ip_count = 2
+}
+
+data "softlayer_lb_vpx" "vpx" {
+    name = "${softlayer_lb_vpx.testacc_foobar_vpx.name}"
+}
+`
@@ -0,0 +1,190 @@
+package softlayer
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	NetworkGatewayMask = "id,name,networkSpace,status[keyName,name]," +
+		"publicIpAddress[ipAddress],privateIpAddress[ipAddress],publicIpv6Address[ipAddress],publicVlanId,privateVlanId," +
+		"members[hardwareId,priority,hardware[hostname,primaryIpAddress,primaryBackendIpAddress]]," +
+		"insideVlans[id,networkVlanId,bypassFlag]"
+)
+
+func dataSourceSoftLayerNetworkGateway() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerNetworkGatewayRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"network_space": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"private_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_ipv6_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_vlan_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"private_vlan_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hardware_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"public_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"associated_vlans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"association_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"network_vlan_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"bypass": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerNetworkGatewayRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetAccountService(sess)
+
+	name := d.Get("name").(string)
+
+	gateways, err := service.
+		Mask(NetworkGatewayMask).
+		Filter(filter.Path("networkGateways.name").Eq(name).Build()).
+		GetNetworkGateways()
+	if err != nil {
+		return fmt.Errorf("Error looking up network gateway: %s", err)
+	}
+
+	if len(gateways) == 0 {
+		return fmt.Errorf("No network gateway was found with the name '%s'", name)
+	}
+
+	gateway := gateways[0]
+
+	d.SetId(fmt.Sprintf("%d", *gateway.Id))
+	d.Set("network_space", sl.Get(gateway.NetworkSpace, ""))
+	d.Set("public_vlan_id", sl.Get(gateway.PublicVlanId, 0))
+	d.Set("private_vlan_id", sl.Get(gateway.PrivateVlanId, 0))
+
+	if gateway.Status != nil {
+		d.Set("status", sl.Get(gateway.Status.KeyName, ""))
+	}
+
+	if gateway.PublicIpAddress != nil {
+		d.Set("public_ip_address", sl.Get(gateway.PublicIpAddress.IpAddress, ""))
+	}
+
+	if gateway.PrivateIpAddress != nil {
+		d.Set("private_ip_address", sl.Get(gateway.PrivateIpAddress.IpAddress, ""))
+	}
+
+	if gateway.PublicIpv6Address != nil {
+		d.Set("public_ipv6_address", sl.Get(gateway.PublicIpv6Address.IpAddress, ""))
+	}
+
+	members := make([]map[string]interface{}, 0, len(gateway.Members))
+	for _, m := range gateway.Members {
+		member := map[string]interface{}{
+			"hardware_id": sl.Get(m.HardwareId, 0),
+			"priority":    sl.Get(m.Priority, 0),
+		}
+		if m.Hardware != nil {
+			member["hostname"] = sl.Get(m.Hardware.Hostname, "")
+			member["public_ip_address"] = sl.Get(m.Hardware.PrimaryIpAddress, "")
+			member["private_ip_address"] = sl.Get(m.Hardware.PrimaryBackendIpAddress, "")
+		}
+		members = append(members, member)
+	}
+	d.Set("members", members)
+
+	vlans := make([]map[string]interface{}, 0, len(gateway.InsideVlans))
+	for _, v := range gateway.InsideVlans {
+		vlans = append(vlans, map[string]interface{}{
+			"association_id":  sl.Get(v.Id, 0),
+			"network_vlan_id": sl.Get(v.NetworkVlanId, 0),
+			"bypass":          sl.Get(v.BypassFlag, false),
+		})
+	}
+	d.Set("associated_vlans", vlans)
+
+	return nil
+}
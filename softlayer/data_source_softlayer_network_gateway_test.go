@@ -0,0 +1,49 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// The network gateway must exist in the account, gateway appliances can't be ordered by the provider
+func TestAccSoftLayerNetworkGatewayDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerNetworkGatewayDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.softlayer_network_gateway.tfacc_gateway",
+						"id",
+						regexp.MustCompile("^[0-9]+$"),
+					),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_network_gateway.tfacc_gateway",
+						"status",
+						"ACTIVE",
+					),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_network_gateway.tfacc_gateway",
+						"members.#",
+						regexp.MustCompile("^[12]$"),
+					),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_network_gateway.tfacc_gateway",
+						"public_ip_address",
+						regexp.MustCompile(`^(([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))\.){3}([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))$`),
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerNetworkGatewayDataSourceConfig_basic = `
+data "softlayer_network_gateway" "tfacc_gateway" {
+    name = "tfacc-gateway"
+}
+`
@@ -0,0 +1,160 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func dataSourceSoftLayerSubnet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerSubnetRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"subnet": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"note": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"network_identifier": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cidr": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"gateway": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"broadcast_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"netmask": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subnet_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ip_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerSubnetRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetAccountService(sess)
+
+	filters := filter.New()
+
+	if subnet, ok := d.GetOk("subnet"); ok {
+		subnetInfo := strings.Split(subnet.(string), "/")
+		if len(subnetInfo) != 2 {
+			return fmt.Errorf("Unable to parse the provided subnet: %s", subnet)
+		}
+		filters = append(filters,
+			filter.Path("subnets.networkIdentifier").Eq(subnetInfo[0]),
+			filter.Path("subnets.cidr").Eq(subnetInfo[1]))
+	}
+
+	if vlanId, ok := d.GetOk("vlan_id"); ok {
+		filters = append(filters, filter.Path("subnets.networkVlanId").Eq(vlanId))
+	}
+
+	if note, ok := d.GetOk("note"); ok {
+		filters = append(filters, filter.Path("subnets.note").Eq(note))
+	}
+
+	if tag, ok := d.GetOk("tag"); ok {
+		filters = append(filters, filter.Path("subnets.tagReferences.tag.name").Eq(tag))
+	}
+
+	if len(filters) == 0 {
+		return errors.New("Missing required properties. Need a subnet, a VLAN ID, a note or a tag.")
+	}
+
+	subnets, err := service.
+		Mask("id,networkIdentifier,cidr,gateway,broadcastAddress,netmask,subnetType,version,note," +
+			"networkVlanId,datacenter[name]").
+		Filter(filters.Build()).
+		GetSubnets()
+	if err != nil {
+		return fmt.Errorf("Error looking up subnet: %s", err)
+	}
+
+	if len(subnets) == 0 {
+		return errors.New("No subnet was found matching the provided properties")
+	}
+
+	if len(subnets) > 1 {
+		return fmt.Errorf("%d subnets were found matching the provided properties, "+
+			"provide more properties to select a single subnet", len(subnets))
+	}
+
+	subnet := subnets[0]
+
+	d.SetId(fmt.Sprintf("%d", *subnet.Id))
+	d.Set("subnet", fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr))
+	d.Set("network_identifier", *subnet.NetworkIdentifier)
+	d.Set("cidr", *subnet.Cidr)
+	d.Set("vlan_id", sl.Get(subnet.NetworkVlanId, 0))
+	d.Set("note", sl.Get(subnet.Note, ""))
+	d.Set("gateway", sl.Get(subnet.Gateway, ""))
+	d.Set("broadcast_address", sl.Get(subnet.BroadcastAddress, ""))
+	d.Set("netmask", sl.Get(subnet.Netmask, ""))
+	d.Set("subnet_type", sl.Get(subnet.SubnetType, ""))
+	d.Set("ip_version", sl.Get(subnet.Version, 4))
+
+	if subnet.Datacenter != nil {
+		d.Set("datacenter", sl.Get(subnet.Datacenter.Name, ""))
+	}
+
+	return nil
+}
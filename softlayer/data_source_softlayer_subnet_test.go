@@ -0,0 +1,71 @@
+package softlayer
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerSubnetDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerSubnetDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerResources("data.softlayer_subnet.tfacc_subnet", "id",
+						"softlayer_subnet.tfacc_subnet", "id"),
+					testAccCheckSoftLayerResources("data.softlayer_subnet.tfacc_subnet", "gateway",
+						"softlayer_subnet.tfacc_subnet", "gateway"),
+					testAccCheckSoftLayerResources("data.softlayer_subnet.tfacc_subnet", "vlan_id",
+						"softlayer_virtual_guest.subnetdsvm1", "private_vlan_id"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_subnet.tfacc_subnet",
+						"cidr",
+						"29",
+					),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_subnet.tfacc_subnet",
+						"netmask",
+						"255.255.255.248",
+					),
+					resource.TestMatchResourceAttr(
+						"data.softlayer_subnet.tfacc_subnet",
+						"broadcast_address",
+						regexp.MustCompile(`^(([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))\.){3}([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))$`),
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerSubnetDataSourceConfig_basic = `
+resource "softlayer_virtual_guest" "subnetdsvm1" {
+    name = "subnetdsvm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_subnet" "tfacc_subnet" {
+    type = "Portable"
+    private = true
+    capacity = 8
+    vlan_id = "${softlayer_virtual_guest.subnetdsvm1.private_vlan_id}"
+    notes = "tfacc_subnet_data_source"
+}
+
+data "softlayer_subnet" "tfacc_subnet" {
+    subnet = "${softlayer_subnet.tfacc_subnet.network_identifier}/${softlayer_subnet.tfacc_subnet.cidr}"
+}
+`
@@ -0,0 +1,254 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+var dnsLabelRegexp = regexp.MustCompile("^(?i)[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?$")
+
+// Record types whose data is the host name of another record
+var dnsHostnameDataTypes = map[string]bool{
+	"cname": true, "mx": true, "ns": true, "ptr": true, "srv": true,
+}
+
+// validateDnsDomainRecordHostValue is the ValidateFunc of the host of a record
+func validateDnsDomainRecordHostValue(v interface{}, k string) (ws []string, errs []error) {
+	if err := validateDnsDomainRecordHost(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q %s", k, err))
+	}
+	return
+}
+
+// validateDnsDomainRecordDataValue is the ValidateFunc of the data of a record. Only the checks which don't
+// depend on the record type can be done here, the others are done by validateDnsDomainRecord. Terraform
+// validates each field on its own, so those run when the record is applied.
+func validateDnsDomainRecordDataValue(v interface{}, k string) (ws []string, errs []error) {
+	value := v.(string)
+	if ip := net.ParseIP(value); ip != nil && ip.To4() == nil && value != strings.ToLower(value) {
+		errs = append(errs, fmt.Errorf(
+			"IPv6 addresses in the data property cannot have upper case letters: %s", value))
+	}
+	if err := validateDnsTxtQuoting(value); err != nil {
+		ws = append(ws, fmt.Sprintf("%q of a txt or spf record may not be parsed as expected: %s", k, err))
+	}
+	return
+}
+
+// validateDnsDomainRecordPort is the ValidateFunc of the port of a srv record
+func validateDnsDomainRecordPort(v interface{}, k string) (ws []string, errs []error) {
+	if port := v.(int); port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("%q must be between 1 and 65535, got %d", k, port))
+	}
+	return
+}
+
+// validateDnsDomainRecordPriority is the ValidateFunc of the 16 bit priorities and weights of mx and srv
+// records
+func validateDnsDomainRecordPriority(v interface{}, k string) (ws []string, errs []error) {
+	if value := v.(int); value < 0 || value > 65535 {
+		errs = append(errs, fmt.Errorf("%q must be between 0 and 65535, got %d", k, value))
+	}
+	return
+}
+
+// validateDnsDomainRecord checks the fields required by the type of a record and the syntax of its host
+// and data
+func validateDnsDomainRecord(record datatypes.Dns_Domain_ResourceRecord) error {
+	recordType := sl.Get(record.Type, "").(string)
+
+	if err := validateDnsDomainRecordHost(sl.Get(record.Host, "").(string)); err != nil {
+		return fmt.Errorf("Invalid host of %s record: %s", recordType, err)
+	}
+
+	switch recordType {
+	case "mx":
+		if record.MxPriority == nil {
+			return fmt.Errorf("mx records require mx_priority")
+		}
+	case "srv":
+		if sl.Get(record.Service, "") == "" || sl.Get(record.Protocol, "") == "" || record.Port == nil {
+			return fmt.Errorf("srv records require service, protocol and port")
+		}
+		if _, errs := validateDnsDomainRecordPort(*record.Port, "port"); len(errs) > 0 {
+			return fmt.Errorf("Invalid srv record: %s", errs[0])
+		}
+	}
+
+	return validateDnsDomainRecordData(recordType, sl.Get(record.Data, "").(string))
+}
+
+// validateDnsDomainRecordData checks the data of a record against its type
+func validateDnsDomainRecordData(recordType, data string) error {
+	if _, errs := validateDnsDomainRecordDataValue(data, "data"); len(errs) > 0 {
+		return errs[0]
+	}
+
+	switch {
+	case recordType == "a":
+		if ip := net.ParseIP(data); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("Data of a record is not a valid IPv4 address: %s", data)
+		}
+	case recordType == "aaaa":
+		if ip := net.ParseIP(data); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("Data of aaaa record is not a valid IPv6 address: %s", data)
+		}
+	case recordType == "txt" || recordType == "spf":
+		if data == "" {
+			return fmt.Errorf("Data of %s record cannot be empty", recordType)
+		}
+		// Quoting mistakes used to be accepted, so they are only reported
+		if err := validateDnsTxtQuoting(data); err != nil {
+			log.Printf("[WARN] Data of %s record may not be parsed as expected: %s", recordType, err)
+		}
+	case dnsHostnameDataTypes[recordType]:
+		// A SRV target of "." means that the service isn't available in the domain
+		if recordType == "srv" && data == "." {
+			return nil
+		}
+		if err := validateDnsHostname(data, false); err != nil {
+			return fmt.Errorf("Data of %s record is not a valid host name: %s", recordType, err)
+		}
+	}
+
+	return nil
+}
+
+// validateDnsDomainRecordSiblings checks a record against the other records of its domain. A CNAME record
+// can't share its host with any other record.
+func validateDnsDomainRecordSiblings(record datatypes.Dns_Domain_ResourceRecord,
+	siblings []datatypes.Dns_Domain_ResourceRecord) error {
+
+	host := strings.ToLower(sl.Get(record.Host, "").(string))
+	recordType := sl.Get(record.Type, "").(string)
+
+	if recordType == "cname" && host == "@" {
+		return fmt.Errorf("A cname record cannot be created for the domain itself")
+	}
+
+	for _, sibling := range siblings {
+		if record.Id != nil && sibling.Id != nil && *record.Id == *sibling.Id {
+			continue
+		}
+
+		if strings.ToLower(sl.Get(sibling.Host, "").(string)) != host {
+			continue
+		}
+
+		siblingType := sl.Get(sibling.Type, "").(string)
+		if recordType == "cname" || siblingType == "cname" {
+			return fmt.Errorf("A %s record cannot be created for host %s, which already has a %s record",
+				recordType, host, siblingType)
+		}
+	}
+
+	return nil
+}
+
+// validateDnsDomainRecordHost checks the host of a record, which is relative to the domain
+func validateDnsDomainRecordHost(host string) error {
+	if host == "@" {
+		return nil
+	}
+	return validateDnsHostname(host, true)
+}
+
+// validateDnsHostname checks the syntax of a host name, optionally fully qualified with a trailing dot
+func validateDnsHostname(name string, allowWildcard bool) error {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return fmt.Errorf("host name cannot be empty")
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("%s is longer than 253 characters", name)
+	}
+
+	for i, label := range strings.Split(name, ".") {
+		if allowWildcard && i == 0 && label == "*" {
+			continue
+		}
+		if !dnsLabelRegexp.MatchString(label) {
+			return fmt.Errorf("%q is not a valid label of %s", label, name)
+		}
+	}
+
+	return nil
+}
+
+// validateDnsTxtQuoting checks that quotes in the data of a TXT record delimit whole character strings
+func validateDnsTxtQuoting(data string) error {
+	// Data without unescaped quotes is a single unquoted string
+	if !strings.Contains(strings.Replace(data, `\"`, "", -1), `"`) {
+		return nil
+	}
+
+	inQuotes := false
+	escaped := false
+	afterString := false
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			if !inQuotes && afterString {
+				return fmt.Errorf("quoted strings must be separated by spaces: %s", data)
+			}
+			inQuotes = !inQuotes
+			afterString = !inQuotes
+		case inQuotes:
+		case c == ' ' || c == '\t':
+			afterString = false
+		default:
+			return fmt.Errorf("text outside of quoted strings: %s", data)
+		}
+	}
+
+	if inQuotes {
+		return fmt.Errorf("unterminated quoted string: %s", data)
+	}
+
+	return nil
+}
+
+// validateDnsDomainRecordInDomain validates a record and checks it against the current records of its domain.
+// Terraform 0.8 has no CustomizeDiff, so these checks across arguments and records can't run at plan time and
+// run when the change is applied instead, before the record is changed.
+func validateDnsDomainRecordInDomain(sess *session.Session, record datatypes.Dns_Domain_ResourceRecord) error {
+	if err := validateDnsDomainRecord(record); err != nil {
+		return err
+	}
+
+	siblings, err := getDnsDomainRecords(sess, *record.DomainId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the records of Dns Domain %d: %s", *record.DomainId, err)
+	}
+
+	return validateDnsDomainRecordSiblings(record, siblings)
+}
+
+// validateDnsDomainRecordSet validates records which are applied together, checking them against each other
+func validateDnsDomainRecordSet(records []datatypes.Dns_Domain_ResourceRecord) error {
+	for i, record := range records {
+		if err := validateDnsDomainRecord(record); err != nil {
+			return err
+		}
+
+		others := make([]datatypes.Dns_Domain_ResourceRecord, 0, len(records)-1)
+		others = append(others, records[:i]...)
+		others = append(others, records[i+1:]...)
+		if err := validateDnsDomainRecordSiblings(record, others); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestValidateDnsDomainRecordData(t *testing.T) {
+	valid := []struct{ recordType, data string }{
+		{"a", "10.0.0.1"},
+		{"aaaa", "2001:db8::1"},
+		{"aaaa", "fe80:0000:0000:0000:0202:b3ff:fe1e:8329"},
+		{"cname", "www.example.com."},
+		{"mx", "mail.example.com"},
+		{"srv", "."},
+		{"ptr", "host-1.example.com"},
+		{"txt", "v=spf1 mx -all"},
+		{"txt", `"v=spf1 " "mx -all"`},
+		{"txt", `say \"hi\"`},
+		// Quoting mistakes are only warnings
+		{"txt", `"unterminated`},
+		{"txt", `text "quoted"`},
+	}
+	for _, v := range valid {
+		if err := validateDnsDomainRecordData(v.recordType, v.data); err != nil {
+			t.Errorf("Expected %s data %s to be valid, got %s", v.recordType, v.data, err)
+		}
+	}
+
+	invalid := []struct{ recordType, data string }{
+		{"a", "2001:db8::1"},
+		{"a", "10.0.0"},
+		{"aaaa", "10.0.0.1"},
+		{"aaaa", "2001:DB8::1"},
+		{"aaaa", "FE80:0000:0000:0000:0202:B3FF:FE1E:8329"},
+		{"cname", "bad host.example.com"},
+		{"mx", "-mail.example.com"},
+		{"txt", ""},
+	}
+	for _, v := range invalid {
+		if err := validateDnsDomainRecordData(v.recordType, v.data); err == nil {
+			t.Errorf("Expected %s data %s to be invalid", v.recordType, v.data)
+		}
+	}
+}
+
+func TestValidateDnsDomainRecordDataValue(t *testing.T) {
+	for _, data := range []string{"v=spf1 mx -all", `"v=spf1 " "mx -all"`, "2001:db8::1"} {
+		if ws, errs := validateDnsDomainRecordDataValue(data, "data"); len(ws) > 0 || len(errs) > 0 {
+			t.Errorf("Expected data %s to be valid, got %q and %q", data, ws, errs)
+		}
+	}
+
+	for _, data := range []string{`"unterminated`, `"one""two"`, `text "quoted"`} {
+		ws, errs := validateDnsDomainRecordDataValue(data, "data")
+		if len(errs) > 0 {
+			t.Errorf("Unexpected errors for data %s: %q", data, errs)
+		}
+		if len(ws) == 0 {
+			t.Errorf("Expected a warning for data %s", data)
+		}
+	}
+
+	if _, errs := validateDnsDomainRecordDataValue("2001:DB8::1", "data"); len(errs) == 0 {
+		t.Error("Expected an error for an upper case IPv6 address")
+	}
+}
+
+func TestValidateDnsDomainRecordPriority(t *testing.T) {
+	for _, value := range []int{0, 10, 65535} {
+		if _, errs := validateDnsDomainRecordPriority(value, "mx_priority"); len(errs) > 0 {
+			t.Errorf("Expected priority %d to be valid, got %q", value, errs)
+		}
+	}
+	for _, value := range []int{-1, 65536} {
+		if _, errs := validateDnsDomainRecordPriority(value, "mx_priority"); len(errs) == 0 {
+			t.Errorf("Expected priority %d to be invalid", value)
+		}
+	}
+
+	for _, value := range []int{0, 65536} {
+		if _, errs := validateDnsDomainRecordPort(value, "port"); len(errs) == 0 {
+			t.Errorf("Expected port %d to be invalid", value)
+		}
+	}
+}
+
+func TestValidateDnsDomainRecord(t *testing.T) {
+	mx := datatypes.Dns_Domain_ResourceRecord{
+		Host: sl.String("@"),
+		Type: sl.String("mx"),
+		Data: sl.String("mail.example.com."),
+	}
+	if err := validateDnsDomainRecord(mx); err == nil {
+		t.Error("Expected an error for a mx record without mx_priority")
+	}
+	mx.MxPriority = sl.Int(10)
+	if err := validateDnsDomainRecord(mx); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+	mx.MxPriority = sl.Int(0)
+	if err := validateDnsDomainRecord(mx); err != nil {
+		t.Errorf("Unexpected error for a mx record with priority 0: %s", err)
+	}
+
+	srv := datatypes.Dns_Domain_ResourceRecord{
+		Host:    sl.String("@"),
+		Type:    sl.String("srv"),
+		Data:    sl.String("sip.example.com."),
+		Service: sl.String("_sip"),
+	}
+	if err := validateDnsDomainRecord(srv); err == nil {
+		t.Error("Expected an error for a srv record without protocol and port")
+	}
+	srv.Protocol = sl.String("_tcp")
+	srv.Port = sl.Int(5060)
+	if err := validateDnsDomainRecord(srv); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	host := datatypes.Dns_Domain_ResourceRecord{
+		Host: sl.String("www..example"),
+		Type: sl.String("a"),
+		Data: sl.String("10.0.0.1"),
+	}
+	if err := validateDnsDomainRecord(host); err == nil {
+		t.Error("Expected an error for an invalid host")
+	}
+	host.Host = sl.String("*.www")
+	if err := validateDnsDomainRecord(host); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestValidateDnsDomainRecordSiblings(t *testing.T) {
+	siblings := []datatypes.Dns_Domain_ResourceRecord{
+		{Id: sl.Int(1), Host: sl.String("www"), Type: sl.String("a"), Data: sl.String("10.0.0.1")},
+		{Id: sl.Int(2), Host: sl.String("ftp"), Type: sl.String("cname"), Data: sl.String("www")},
+	}
+
+	cname := datatypes.Dns_Domain_ResourceRecord{Host: sl.String("WWW"), Type: sl.String("cname"), Data: sl.String("web")}
+	if err := validateDnsDomainRecordSiblings(cname, siblings); err == nil {
+		t.Error("Expected an error for a cname record sharing its host")
+	}
+
+	a := datatypes.Dns_Domain_ResourceRecord{Host: sl.String("ftp"), Type: sl.String("a"), Data: sl.String("10.0.0.2")}
+	if err := validateDnsDomainRecordSiblings(a, siblings); err == nil {
+		t.Error("Expected an error for a record sharing the host of a cname record")
+	}
+
+	// A record doesn't conflict with itself when it is updated
+	edited := datatypes.Dns_Domain_ResourceRecord{Id: sl.Int(2), Host: sl.String("ftp"), Type: sl.String("cname"), Data: sl.String("web")}
+	if err := validateDnsDomainRecordSiblings(edited, siblings); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+
+	apex := datatypes.Dns_Domain_ResourceRecord{Host: sl.String("@"), Type: sl.String("cname"), Data: sl.String("web")}
+	if err := validateDnsDomainRecordSiblings(apex, nil); err == nil {
+		t.Error("Expected an error for a cname record of the domain itself")
+	}
+}
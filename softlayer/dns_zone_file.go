@@ -0,0 +1,393 @@
+package softlayer
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const defaultZoneFileTtl = 86400
+
+// zoneFileEntry is a logical line of a zone file, after comments are removed and
+// parenthesized continuations are joined.
+type zoneFileEntry struct {
+	line           int
+	tokens         []string
+	inheritedOwner bool
+}
+
+// parseZoneFile parses an RFC 1035 master file for the given domain into resource records. SOA records and
+// the NS records of the domain itself are skipped, as SoftLayer manages them.
+func parseZoneFile(contents, domain string) ([]datatypes.Dns_Domain_ResourceRecord, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	entries, err := splitZoneFile(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := domain + "."
+	ttl := defaultZoneFileTtl
+	owner := ""
+
+	records := make([]datatypes.Dns_Domain_ResourceRecord, 0, len(entries))
+	for _, entry := range entries {
+		tokens := entry.tokens
+
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN expects a domain name", entry.line)
+			}
+			origin = zoneFileAbsoluteName(tokens[1], origin)
+			continue
+		case "$TTL":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL expects a TTL", entry.line)
+			}
+			ttl, err = parseZoneFileTtl(tokens[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", entry.line, err)
+			}
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s is not supported", entry.line, tokens[0])
+		}
+
+		if !entry.inheritedOwner {
+			owner = zoneFileAbsoluteName(tokens[0], origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: no owner name for the record", entry.line)
+		}
+
+		// The TTL and class are optional and may come in any order before the type
+		recordTtl := ttl
+		for len(tokens) > 0 {
+			if t, err := parseZoneFileTtl(tokens[0]); err == nil {
+				recordTtl = t
+			} else if strings.ToUpper(tokens[0]) == "IN" {
+				// Internet class, the only one supported
+			} else if class := strings.ToUpper(tokens[0]); class == "CH" || class == "HS" || class == "CS" {
+				return nil, fmt.Errorf("line %d: class %s is not supported", entry.line, class)
+			} else {
+				break
+			}
+			tokens = tokens[1:]
+		}
+
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", entry.line)
+		}
+
+		recordType := strings.ToLower(tokens[0])
+		rdata := tokens[1:]
+
+		if recordType == "soa" || (recordType == "ns" && strings.TrimSuffix(owner, ".") == domain) {
+			continue
+		}
+
+		host, err := zoneFileRelativeHost(owner, domain)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", entry.line, err)
+		}
+
+		record := datatypes.Dns_Domain_ResourceRecord{
+			Host: sl.String(host),
+			Type: sl.String(recordType),
+			Ttl:  sl.Int(recordTtl),
+		}
+
+		err = parseZoneFileRdata(&record, rdata, origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", entry.line, err)
+		}
+
+		err = validateDnsDomainRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", entry.line, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseZoneFileRdata(record *datatypes.Dns_Domain_ResourceRecord, rdata []string, origin string) error {
+	recordType := *record.Type
+
+	expect := func(n int) error {
+		if len(rdata) != n {
+			return fmt.Errorf("%s record expects %d fields, got %d", strings.ToUpper(recordType), n, len(rdata))
+		}
+		return nil
+	}
+
+	switch recordType {
+	case "a":
+		if err := expect(1); err != nil {
+			return err
+		}
+		if ip := net.ParseIP(rdata[0]); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%s is not a valid IPv4 address", rdata[0])
+		}
+		record.Data = sl.String(rdata[0])
+
+	case "aaaa":
+		if err := expect(1); err != nil {
+			return err
+		}
+		if ip := net.ParseIP(rdata[0]); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%s is not a valid IPv6 address", rdata[0])
+		}
+		record.Data = sl.String(rdata[0])
+
+	case "cname", "ns":
+		if err := expect(1); err != nil {
+			return err
+		}
+		record.Data = sl.String(zoneFileAbsoluteName(rdata[0], origin))
+
+	case "mx":
+		if err := expect(2); err != nil {
+			return err
+		}
+		priority, err := strconv.Atoi(rdata[0])
+		if err != nil {
+			return fmt.Errorf("invalid MX priority %s", rdata[0])
+		}
+		record.MxPriority = sl.Int(priority)
+		record.Data = sl.String(zoneFileAbsoluteName(rdata[1], origin))
+
+	case "txt", "spf":
+		if len(rdata) == 0 {
+			return fmt.Errorf("%s record expects text", strings.ToUpper(recordType))
+		}
+		record.Data = sl.String(joinZoneFileStrings(rdata))
+
+	case "srv":
+		if err := expect(4); err != nil {
+			return err
+		}
+		values := make([]int, 3)
+		for i := range values {
+			v, err := strconv.Atoi(rdata[i])
+			if err != nil {
+				return fmt.Errorf("invalid SRV priority, weight or port %s", rdata[i])
+			}
+			values[i] = v
+		}
+
+		// The owner of a SRV record is _service._protocol.name
+		labels := strings.SplitN(*record.Host, ".", 3)
+		if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return fmt.Errorf("SRV record owner %s must start with _service._protocol", *record.Host)
+		}
+		record.Service = sl.String(labels[0])
+		record.Protocol = sl.String(labels[1])
+		if len(labels) == 3 {
+			record.Host = sl.String(labels[2])
+		} else {
+			record.Host = sl.String("@")
+		}
+
+		record.Priority = sl.Int(values[0])
+		record.Weight = sl.Int(values[1])
+		record.Port = sl.Int(values[2])
+		record.Data = sl.String(zoneFileAbsoluteName(rdata[3], origin))
+
+	default:
+		return fmt.Errorf("record type %s is not supported", strings.ToUpper(recordType))
+	}
+
+	return nil
+}
+
+// splitZoneFile removes comments and joins the lines of parenthesized records into entries of tokens
+func splitZoneFile(contents string) ([]zoneFileEntry, error) {
+	entries := []zoneFileEntry{}
+
+	var current *zoneFileEntry
+	depth := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+
+		tokens, opened, err := tokenizeZoneFileLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+
+		if current == nil {
+			if len(tokens) == 0 && opened == 0 {
+				continue
+			}
+			current = &zoneFileEntry{
+				line:           lineNumber,
+				inheritedOwner: len(line) > 0 && unicode.IsSpace(rune(line[0])),
+			}
+		}
+
+		current.tokens = append(current.tokens, tokens...)
+		depth += opened
+
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+		}
+
+		if depth == 0 {
+			if len(current.tokens) > 0 {
+				entries = append(entries, *current)
+			}
+			current = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.line)
+	}
+
+	return entries, nil
+}
+
+// tokenizeZoneFileLine splits a line into tokens, keeping quoted strings with their quotes. It returns
+// the number of opened minus closed parentheses.
+func tokenizeZoneFileLine(line string) (tokens []string, opened int, err error) {
+	var token []rune
+	inQuotes := false
+	escaped := false
+
+	flush := func() {
+		if len(token) > 0 {
+			tokens = append(tokens, string(token))
+			token = nil
+		}
+	}
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			token = append(token, c)
+			escaped = false
+		case c == '\\':
+			token = append(token, c)
+			escaped = true
+		case c == '"':
+			token = append(token, c)
+			inQuotes = !inQuotes
+			if !inQuotes {
+				flush()
+			}
+		case inQuotes:
+			token = append(token, c)
+		case c == ';':
+			flush()
+			return tokens, opened, nil
+		case c == '(':
+			flush()
+			opened++
+		case c == ')':
+			flush()
+			opened--
+		case unicode.IsSpace(c):
+			flush()
+		default:
+			token = append(token, c)
+		}
+	}
+
+	if inQuotes {
+		return nil, 0, fmt.Errorf("unterminated quoted string")
+	}
+
+	flush()
+	return tokens, opened, nil
+}
+
+// joinZoneFileStrings concatenates the character strings of a TXT record
+func joinZoneFileStrings(tokens []string) string {
+	parts := make([]string, 0, len(tokens))
+	quoted := false
+	for _, token := range tokens {
+		if len(token) >= 2 && strings.HasPrefix(token, `"`) && strings.HasSuffix(token, `"`) {
+			quoted = true
+			token = token[1 : len(token)-1]
+		}
+		parts = append(parts, strings.Replace(token, `\"`, `"`, -1))
+	}
+
+	if quoted {
+		return strings.Join(parts, "")
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseZoneFileTtl parses a TTL in seconds, or with BIND units such as 1h30m
+func parseZoneFileTtl(value string) (int, error) {
+	if ttl, err := strconv.Atoi(value); err == nil && ttl >= 0 {
+		return ttl, nil
+	}
+
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+
+	total := 0
+	number := ""
+	for _, c := range strings.ToLower(value) {
+		if unicode.IsDigit(c) {
+			number += string(c)
+			continue
+		}
+
+		unit, ok := units[c]
+		if !ok || number == "" {
+			return 0, fmt.Errorf("invalid TTL %s", value)
+		}
+
+		n, _ := strconv.Atoi(number)
+		total += n * unit
+		number = ""
+	}
+
+	if number != "" || total == 0 {
+		return 0, fmt.Errorf("invalid TTL %s", value)
+	}
+
+	return total, nil
+}
+
+// zoneFileAbsoluteName returns a name relative to the origin as a fully qualified name
+func zoneFileAbsoluteName(name, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(name) + "." + origin
+}
+
+// zoneFileRelativeHost returns the host of a fully qualified name within the domain, as used by SoftLayer
+func zoneFileRelativeHost(name, domain string) (string, error) {
+	name = strings.TrimSuffix(name, ".")
+	if name == domain {
+		return "@", nil
+	}
+	if strings.HasSuffix(name, "."+domain) {
+		return strings.TrimSuffix(name, "."+domain), nil
+	}
+	return "", fmt.Errorf("%s is outside of domain %s", name, domain)
+}
@@ -0,0 +1,106 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const testZoneFile = `
+$ORIGIN example.com.
+$TTL 1h
+@       IN  SOA ns1.softlayer.com. root.example.com. (
+                2017010101 ; serial
+                7200       ; refresh
+                600        ; retry
+                1728000    ; expire
+                43200 )    ; minimum
+@           IN  NS    ns1.softlayer.com.
+@           IN  A     10.0.0.1
+www     300 IN  A     10.0.0.2
+            IN  AAAA  fe80::202:b3ff:fe1e:8329
+mail.example.com. IN MX 10 mx1
+ftp         IN  CNAME www.example.com.
+@           IN  TXT   "v=spf1 " "mx -all" ; split string
+sub         IN  NS    ns.other.net.
+_sip._tcp   IN  SRV   10 20 5060 sip.example.com.
+_xmpp._tcp.chat 1d SRV 5 0 5222 chat
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, err := parseZoneFile(testZoneFile, "example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []struct {
+		host, recordType, data string
+		ttl                    int
+	}{
+		{"@", "a", "10.0.0.1", 3600},
+		{"www", "a", "10.0.0.2", 300},
+		{"www", "aaaa", "fe80::202:b3ff:fe1e:8329", 3600},
+		{"mail", "mx", "mx1.example.com.", 3600},
+		{"ftp", "cname", "www.example.com.", 3600},
+		{"@", "txt", "v=spf1 mx -all", 3600},
+		{"sub", "ns", "ns.other.net.", 3600},
+		{"@", "srv", "sip.example.com.", 3600},
+		{"chat", "srv", "chat.example.com.", 86400},
+	}
+
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d", len(expected), len(records))
+	}
+
+	for i, e := range expected {
+		r := records[i]
+		if *r.Host != e.host || *r.Type != e.recordType || *r.Data != e.data || *r.Ttl != e.ttl {
+			t.Errorf("Record %d: expected %s %s %s %d, got %s %s %s %d", i,
+				e.host, e.recordType, e.data, e.ttl, *r.Host, *r.Type, *r.Data, *r.Ttl)
+		}
+	}
+
+	if sl.Get(records[3].MxPriority, 0) != 10 {
+		t.Errorf("Expected MX priority 10, got %v", sl.Get(records[3].MxPriority, 0))
+	}
+
+	srv := records[7]
+	if *srv.Service != "_sip" || *srv.Protocol != "_tcp" || *srv.Priority != 10 || *srv.Weight != 20 || *srv.Port != 5060 {
+		t.Errorf("Unexpected SRV record fields: %s %s %d %d %d",
+			*srv.Service, *srv.Protocol, *srv.Priority, *srv.Weight, *srv.Port)
+	}
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	cases := map[string]string{
+		"outside of domain":   "www.other.com. IN A 10.0.0.1",
+		"invalid address":     "www IN A 10.0.0",
+		"upper case IPv6":     "www IN AAAA FE80:0000:0000:0000:0202:B3FF:FE1E:8329",
+		"unsupported type":    "www IN HINFO \"x86\" \"linux\"",
+		"unbalanced":          "www IN TXT ( \"a\"",
+		"unterminated string": "www IN TXT \"abc",
+		"srv owner":           "www IN SRV 1 2 3 target",
+		"include":             "$INCLUDE other.zone",
+		"missing owner":       "    IN A 10.0.0.1",
+	}
+
+	for name, zone := range cases {
+		if _, err := parseZoneFile(zone, "example.com"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseZoneFileTtl(t *testing.T) {
+	cases := map[string]int{"300": 300, "1h": 3600, "1h30m": 5400, "1W": 604800, "2d": 172800}
+	for value, expected := range cases {
+		ttl, err := parseZoneFileTtl(value)
+		if err != nil || ttl != expected {
+			t.Errorf("%s: expected %d, got %d (%v)", value, expected, ttl, err)
+		}
+	}
+
+	if _, err := parseZoneFileTtl("1x"); err == nil {
+		t.Error("Expected an error for an invalid unit")
+	}
+}
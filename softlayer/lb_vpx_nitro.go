@@ -0,0 +1,655 @@
+package softlayer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+)
+
+// The NITRO configuration of the VIPs and services of a VPX which are managed through its NITRO API instead
+// of the SoftLayer API. Each change is saved, so that it survives a reboot of the VPX.
+
+const nitroSslPath = "/nsconfig/ssl/"
+
+type nitroVserver struct {
+	Name            string   `json:"name"`
+	ServiceType     string   `json:"servicetype,omitempty"`
+	IPv46           string   `json:"ipv46,omitempty"`
+	Port            nitroInt `json:"port,omitempty"`
+	LbMethod        string   `json:"lbmethod,omitempty"`
+	PersistenceType string   `json:"persistencetype,omitempty"`
+	Timeout         nitroInt `json:"timeout,omitempty"`
+}
+
+type nitroCsPolicy struct {
+	PolicyName string `json:"policyname"`
+	Rule       string `json:"rule,omitempty"`
+}
+
+type nitroCsPolicyBinding struct {
+	Name            string   `json:"name"`
+	PolicyName      string   `json:"policyname"`
+	TargetLbVserver string   `json:"targetlbvserver,omitempty"`
+	Priority        nitroInt `json:"priority,omitempty"`
+}
+
+type nitroSslCertKeyBinding struct {
+	VserverName string `json:"vservername"`
+	CertKeyName string `json:"certkeyname"`
+	Ca          bool   `json:"ca,omitempty"`
+}
+
+type nitroService struct {
+	Name        string   `json:"name"`
+	Ip          string   `json:"ip,omitempty"`
+	IpAddress   string   `json:"ipaddress,omitempty"`
+	Port        nitroInt `json:"port,omitempty"`
+	ServiceType string   `json:"servicetype,omitempty"`
+	MaxClient   nitroInt `json:"maxclient,omitempty"`
+}
+
+type nitroServiceBinding struct {
+	Name        string   `json:"name"`
+	ServiceName string   `json:"servicename"`
+	Weight      nitroInt `json:"weight,omitempty"`
+}
+
+type nitroMonitor struct {
+	MonitorName string   `json:"monitorname"`
+	Type        string   `json:"type,omitempty"`
+	HttpRequest string   `json:"httprequest,omitempty"`
+	RespCode    []string `json:"respcode,omitempty"`
+	Recv        string   `json:"recv,omitempty"`
+	Interval    nitroInt `json:"interval,omitempty"`
+	RespTimeout nitroInt `json:"resptimeout,omitempty"`
+}
+
+type nitroMonitorBinding struct {
+	Name        string `json:"name"`
+	MonitorName string `json:"monitor_name"`
+}
+
+// nitroCertKey is a certificate and key pair installed on a VPX. Only the name is known when it is read back.
+type nitroCertKey struct {
+	Name         string
+	Certificate  string
+	PrivateKey   string
+	Intermediate string
+}
+
+// nitroCsRule is a content switching policy of a VIP, which sends the requests matching an expression to
+// another VIP
+type nitroCsRule struct {
+	Name       string
+	Expression string
+	Target     string
+	Priority   int
+}
+
+// nitroVip is a load balancing virtual server, or a content switching virtual server when it has rules
+type nitroVip struct {
+	Vserver nitroVserver
+	CertKey *nitroCertKey
+	Rules   []nitroCsRule
+}
+
+func (vip nitroVip) vserverType() string {
+	if len(vip.Rules) > 0 {
+		return "csvserver"
+	}
+	return "lbvserver"
+}
+
+// nitroLbService is a service bound to a load balancing virtual server. A monitor without type is one of
+// the built-in monitors of the VPX, else it is created with the service.
+type nitroLbService struct {
+	Service nitroService
+	VipName string
+	Weight  int
+	Monitor nitroMonitor
+}
+
+func isNitroAlreadyExists(err error) bool {
+	if nErr, ok := err.(*nitroError); ok {
+		return nErr.ErrorCode == 273 || strings.Contains(strings.ToLower(nErr.Message), "already exists")
+	}
+	return false
+}
+
+func createNitroVip(c *nitroClient, vip nitroVip) error {
+	if vip.CertKey != nil {
+		if err := ensureNitroCertKey(c, *vip.CertKey); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] Creating NITRO %s %s", vip.vserverType(), vip.Vserver.Name)
+
+	vserver := vip.Vserver
+	if len(vip.Rules) > 0 {
+		// Content switching virtual servers have no load balancing or persistence of their own
+		vserver.LbMethod = ""
+		vserver.PersistenceType = ""
+		vserver.Timeout = 0
+	}
+
+	if err := c.add(vip.vserverType(), vserver); err != nil {
+		return err
+	}
+
+	if err := addNitroCsRules(c, vip.Vserver.Name, vip.Rules); err != nil {
+		return err
+	}
+
+	if vip.CertKey != nil {
+		err := c.bind("sslvserver_sslcertkey_binding", nitroSslCertKeyBinding{
+			VserverName: vip.Vserver.Name,
+			CertKeyName: vip.CertKey.Name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.saveConfig()
+}
+
+func readNitroVip(c *nitroClient, name string) (nitroVip, error) {
+	vip := nitroVip{}
+
+	var vservers []nitroVserver
+	err := c.get("lbvserver", name, &vservers)
+	if isNitroNotFound(err) {
+		err = c.get("csvserver", name, &vservers)
+		if err == nil {
+			vip.Rules, err = readNitroCsRules(c, name)
+		}
+	}
+	if err != nil {
+		return vip, err
+	}
+
+	if len(vservers) != 1 {
+		return vip, &nitroError{StatusCode: 404, Message: fmt.Sprintf("No virtual server named %s", name)}
+	}
+	vip.Vserver = vservers[0]
+
+	if strings.HasPrefix(vip.Vserver.ServiceType, "SSL") {
+		var bindings []nitroSslCertKeyBinding
+		if err := c.get("sslvserver_sslcertkey_binding", name, &bindings); err != nil {
+			return vip, err
+		}
+		for _, binding := range bindings {
+			if !binding.Ca {
+				vip.CertKey = &nitroCertKey{Name: binding.CertKeyName}
+			}
+		}
+	}
+
+	return vip, nil
+}
+
+// updateNitroVip changes a VIP from its old configuration to its new one. The properties which can't be
+// updated make it recreate the virtual server, keeping the services bound to it.
+func updateNitroVip(c *nitroClient, old, new nitroVip) error {
+	if old.vserverType() != new.vserverType() || old.Vserver.ServiceType != new.Vserver.ServiceType {
+		var bindings []nitroServiceBinding
+		if old.vserverType() == "lbvserver" {
+			if err := c.get("lbvserver_service_binding", old.Vserver.Name, &bindings); err != nil {
+				return err
+			}
+		}
+
+		if len(bindings) > 0 && new.vserverType() != "lbvserver" {
+			return fmt.Errorf("Services are bound to VIP %s, they must be removed before adding content switching rules",
+				old.Vserver.Name)
+		}
+
+		if err := deleteNitroVip(c, old); err != nil {
+			return err
+		}
+		if err := createNitroVip(c, new); err != nil {
+			return err
+		}
+
+		for _, binding := range bindings {
+			if err := c.bind("lbvserver_service_binding", binding); err != nil {
+				return err
+			}
+		}
+
+		return c.saveConfig()
+	}
+
+	vserver := nitroVserver{
+		Name:  new.Vserver.Name,
+		IPv46: new.Vserver.IPv46,
+	}
+	if new.vserverType() == "lbvserver" {
+		vserver.LbMethod = new.Vserver.LbMethod
+		vserver.PersistenceType = new.Vserver.PersistenceType
+		vserver.Timeout = new.Vserver.Timeout
+	}
+
+	if err := c.update(new.vserverType(), vserver); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(old.Rules, new.Rules) {
+		if err := removeNitroCsRules(c, old.Vserver.Name, old.Rules); err != nil {
+			return err
+		}
+		if err := addNitroCsRules(c, new.Vserver.Name, new.Rules); err != nil {
+			return err
+		}
+	}
+
+	if old.CertKey != nil && new.CertKey != nil && old.CertKey.Name != new.CertKey.Name {
+		err := c.unbind("sslvserver_sslcertkey_binding", old.Vserver.Name, "certkeyname:"+old.CertKey.Name)
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+
+		if err := ensureNitroCertKey(c, *new.CertKey); err != nil {
+			return err
+		}
+
+		err = c.bind("sslvserver_sslcertkey_binding", nitroSslCertKeyBinding{
+			VserverName: new.Vserver.Name,
+			CertKeyName: new.CertKey.Name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.saveConfig()
+}
+
+// deleteNitroVip deletes a virtual server, with its bindings, and its content switching policies. Certificates
+// are left on the VPX, as other VIPs may use them.
+func deleteNitroVip(c *nitroClient, vip nitroVip) error {
+	log.Printf("[INFO] Deleting NITRO %s %s", vip.vserverType(), vip.Vserver.Name)
+
+	err := c.remove(vip.vserverType(), vip.Vserver.Name, "")
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+
+	for _, rule := range vip.Rules {
+		err := c.remove("cspolicy", rule.Name, "")
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+	}
+
+	return c.saveConfig()
+}
+
+// ensureNitroCertKey uploads a certificate and its key to the VPX, unless they are already installed
+func ensureNitroCertKey(c *nitroClient, certKey nitroCertKey) error {
+	files := map[string]string{
+		certKey.Name + ".crt": certKey.Certificate,
+		certKey.Name + ".key": certKey.PrivateKey,
+	}
+	if certKey.Intermediate != "" {
+		files[certKey.Name+"-ca.crt"] = certKey.Intermediate
+	}
+
+	for fileName, content := range files {
+		err := c.add("systemfile", map[string]interface{}{
+			"filename":     fileName,
+			"filelocation": nitroSslPath,
+			"filecontent":  base64.StdEncoding.EncodeToString([]byte(content)),
+			"fileencoding": "BASE64",
+		})
+		if err != nil && !isNitroAlreadyExists(err) {
+			return err
+		}
+	}
+
+	err := c.add("sslcertkey", map[string]interface{}{
+		"certkey": certKey.Name,
+		"cert":    nitroSslPath + certKey.Name + ".crt",
+		"key":     nitroSslPath + certKey.Name + ".key",
+	})
+	if err != nil {
+		if isNitroAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+
+	if certKey.Intermediate == "" {
+		return nil
+	}
+
+	err = c.add("sslcertkey", map[string]interface{}{
+		"certkey": certKey.Name + "-ca",
+		"cert":    nitroSslPath + certKey.Name + "-ca.crt",
+	})
+	if err != nil && !isNitroAlreadyExists(err) {
+		return err
+	}
+
+	return c.action("sslcertkey", "link", map[string]interface{}{
+		"certkey":         certKey.Name,
+		"linkcertkeyname": certKey.Name + "-ca",
+	})
+}
+
+func addNitroCsRules(c *nitroClient, vserverName string, rules []nitroCsRule) error {
+	for _, rule := range rules {
+		err := c.add("cspolicy", nitroCsPolicy{PolicyName: rule.Name, Rule: rule.Expression})
+		if err != nil {
+			return err
+		}
+
+		err = c.bind("csvserver_cspolicy_binding", nitroCsPolicyBinding{
+			Name:            vserverName,
+			PolicyName:      rule.Name,
+			TargetLbVserver: rule.Target,
+			Priority:        nitroInt(rule.Priority),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func removeNitroCsRules(c *nitroClient, vserverName string, rules []nitroCsRule) error {
+	for _, rule := range rules {
+		err := c.unbind("csvserver_cspolicy_binding", vserverName, "policyname:"+rule.Name)
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+
+		err = c.remove("cspolicy", rule.Name, "")
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readNitroCsRules(c *nitroClient, vserverName string) ([]nitroCsRule, error) {
+	var bindings []nitroCsPolicyBinding
+	if err := c.get("csvserver_cspolicy_binding", vserverName, &bindings); err != nil {
+		return nil, err
+	}
+
+	rules := make([]nitroCsRule, 0, len(bindings))
+	for _, binding := range bindings {
+		var policies []nitroCsPolicy
+		if err := c.get("cspolicy", binding.PolicyName, &policies); err != nil {
+			return nil, err
+		}
+
+		rule := nitroCsRule{
+			Name:     binding.PolicyName,
+			Target:   binding.TargetLbVserver,
+			Priority: int(binding.Priority),
+		}
+		if len(policies) > 0 {
+			rule.Expression = policies[0].Rule
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func createNitroService(c *nitroClient, s nitroLbService) error {
+	log.Printf("[INFO] Creating NITRO service %s", s.Service.Name)
+
+	if err := c.add("service", s.Service); err != nil {
+		return err
+	}
+
+	if err := addNitroServiceMonitor(c, s.Service.Name, s.Monitor); err != nil {
+		return err
+	}
+
+	err := c.bind("lbvserver_service_binding", nitroServiceBinding{
+		Name:        s.VipName,
+		ServiceName: s.Service.Name,
+		Weight:      nitroInt(s.Weight),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.saveConfig()
+}
+
+func readNitroService(c *nitroClient, vipName, name string) (nitroLbService, error) {
+	s := nitroLbService{VipName: vipName}
+
+	var nitroServices []nitroService
+	if err := c.get("service", name, &nitroServices); err != nil {
+		return s, err
+	}
+	if len(nitroServices) != 1 {
+		return s, &nitroError{StatusCode: 404, Message: fmt.Sprintf("No service named %s", name)}
+	}
+	s.Service = nitroServices[0]
+
+	var bindings []nitroServiceBinding
+	if err := c.get("lbvserver_service_binding", vipName, &bindings); err != nil {
+		return s, err
+	}
+	for _, binding := range bindings {
+		if binding.ServiceName == name {
+			s.Weight = int(binding.Weight)
+		}
+	}
+
+	var monitorBindings []nitroMonitorBinding
+	if err := c.get("service_lbmonitor_binding", name, &monitorBindings); err != nil {
+		return s, err
+	}
+	if len(monitorBindings) == 0 {
+		return s, nil
+	}
+
+	s.Monitor = nitroMonitor{MonitorName: monitorBindings[0].MonitorName}
+	if s.Monitor.MonitorName == nitroServiceMonitorName(name) {
+		var monitors []nitroMonitor
+		if err := c.get("lbmonitor", s.Monitor.MonitorName, &monitors); err != nil {
+			return s, err
+		}
+		if len(monitors) > 0 {
+			s.Monitor = monitors[0]
+		}
+	}
+
+	return s, nil
+}
+
+func updateNitroService(c *nitroClient, old, new nitroLbService) error {
+	if old.Service.MaxClient != new.Service.MaxClient {
+		err := c.update("service", nitroService{Name: new.Service.Name, MaxClient: new.Service.MaxClient})
+		if err != nil {
+			return err
+		}
+	}
+
+	if old.Weight != new.Weight {
+		err := c.unbind("lbvserver_service_binding", old.VipName, "servicename:"+old.Service.Name)
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+
+		err = c.bind("lbvserver_service_binding", nitroServiceBinding{
+			Name:        new.VipName,
+			ServiceName: new.Service.Name,
+			Weight:      nitroInt(new.Weight),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !reflect.DeepEqual(old.Monitor, new.Monitor) {
+		if err := removeNitroServiceMonitor(c, old.Service.Name, old.Monitor); err != nil {
+			return err
+		}
+		if err := addNitroServiceMonitor(c, new.Service.Name, new.Monitor); err != nil {
+			return err
+		}
+	}
+
+	return c.saveConfig()
+}
+
+// deleteNitroService deletes a service, with its bindings, and its custom monitor
+func deleteNitroService(c *nitroClient, s nitroLbService) error {
+	log.Printf("[INFO] Deleting NITRO service %s", s.Service.Name)
+
+	err := c.remove("service", s.Service.Name, "")
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+
+	if s.Monitor.Type != "" {
+		err := c.remove("lbmonitor", s.Monitor.MonitorName, "type:"+s.Monitor.Type)
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+	}
+
+	return c.saveConfig()
+}
+
+// nitroServiceMonitorName is the name of the custom monitor of a service
+func nitroServiceMonitorName(serviceName string) string {
+	return serviceName + "-monitor"
+}
+
+func addNitroServiceMonitor(c *nitroClient, serviceName string, monitor nitroMonitor) error {
+	if monitor.MonitorName == "" {
+		return nil
+	}
+
+	if monitor.Type != "" {
+		if err := c.add("lbmonitor", monitor); err != nil {
+			return err
+		}
+	}
+
+	return c.bind("service_lbmonitor_binding", nitroMonitorBinding{
+		Name:        serviceName,
+		MonitorName: monitor.MonitorName,
+	})
+}
+
+func removeNitroServiceMonitor(c *nitroClient, serviceName string, monitor nitroMonitor) error {
+	if monitor.MonitorName == "" {
+		return nil
+	}
+
+	err := c.unbind("service_lbmonitor_binding", serviceName, "monitor_name:"+monitor.MonitorName)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+
+	if monitor.Type != "" {
+		err := c.remove("lbmonitor", monitor.MonitorName, "type:"+monitor.Type)
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The SoftLayer names of load balancing methods and health checks, and the NITRO names they map to
+var nitroLbMethods = map[string]string{
+	"rr":  "ROUNDROBIN",
+	"lc":  "LEASTCONNECTION",
+	"sr":  "LEASTRESPONSETIME",
+	"sh":  "SOURCEIPHASH",
+	"pi":  "LEASTPACKETS",
+	"bw":  "LEASTBANDWIDTH",
+	"url": "URLHASH",
+}
+
+var nitroBuiltinMonitors = map[string]string{
+	"HTTP": "http",
+	"TCP":  "tcp",
+	"ICMP": "ping",
+	"DNS":  "dns",
+}
+
+// nitroLbMethod returns the NITRO name of a load balancing method, which is either a SoftLayer or a NITRO name
+func nitroLbMethod(method string) string {
+	if nitroMethod, ok := nitroLbMethods[strings.ToLower(method)]; ok {
+		return nitroMethod
+	}
+	return strings.ToUpper(method)
+}
+
+// nitroBuiltinMonitor returns the name of the built-in monitor of a SoftLayer health check
+func nitroBuiltinMonitor(healthCheck string) string {
+	if monitor, ok := nitroBuiltinMonitors[strings.ToUpper(healthCheck)]; ok {
+		return monitor
+	}
+	return strings.ToLower(healthCheck)
+}
+
+// lbVpxHealthCheck returns the SoftLayer health check of a built-in monitor
+func lbVpxHealthCheck(monitor string) string {
+	for healthCheck, builtinMonitor := range nitroBuiltinMonitors {
+		if builtinMonitor == monitor {
+			return healthCheck
+		}
+	}
+	return strings.ToUpper(monitor)
+}
+
+// nitroVserverServiceType returns the NITRO service type of a VIP, which terminates SSL when it has a certificate
+func nitroVserverServiceType(vipType string, ssl bool) (string, error) {
+	serviceType := strings.ToUpper(vipType)
+	if !ssl {
+		return serviceType, nil
+	}
+
+	switch serviceType {
+	case "HTTP":
+		return "SSL", nil
+	case "TCP":
+		return "SSL_TCP", nil
+	}
+
+	return "", fmt.Errorf("SSL offload is only supported by HTTP and TCP VIPs, not %s", vipType)
+}
+
+// lbVpxVipType returns the type of a VIP, or the protocol of its services, from its NITRO service type
+func lbVpxVipType(serviceType string) string {
+	switch serviceType {
+	case "SSL":
+		return "HTTP"
+	case "SSL_TCP":
+		return "TCP"
+	}
+	return serviceType
+}
+
+func nitroCertKeyName(certificateId int) string {
+	return fmt.Sprintf("sl-cert-%d", certificateId)
+}
+
+// parseNitroCertKeyName returns the ID of the SoftLayer certificate of a cert key, or 0 if it wasn't
+// installed by the provider
+func parseNitroCertKeyName(name string) int {
+	var certificateId int
+	if _, err := fmt.Sscanf(name, "sl-cert-%d", &certificateId); err != nil || nitroCertKeyName(certificateId) != name {
+		return 0
+	}
+	return certificateId
+}
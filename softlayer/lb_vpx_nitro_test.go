@@ -0,0 +1,466 @@
+package softlayer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeNitro is an in-memory stand-in of the NITRO API of a VPX, which supports the resources used by the
+// provider
+type fakeNitro struct {
+	sync.Mutex
+	objects map[string][]map[string]interface{}
+	actions []string
+}
+
+// The properties identifying the resources of each type. Bindings are identified by the bound resources.
+var fakeNitroKeys = map[string][]string{
+	"lbvserver":                     {"name"},
+	"csvserver":                     {"name"},
+	"service":                       {"name"},
+	"cspolicy":                      {"policyname"},
+	"lbmonitor":                     {"monitorname"},
+	"sslcertkey":                    {"certkey"},
+	"systemfile":                    {"filename"},
+	"lbvserver_service_binding":     {"name", "servicename"},
+	"csvserver_cspolicy_binding":    {"name", "policyname"},
+	"service_lbmonitor_binding":     {"name", "monitor_name"},
+	"sslvserver_sslcertkey_binding": {"vservername", "certkeyname"},
+	"nsconfig":                      {"name"},
+}
+
+func newFakeNitro() (*fakeNitro, *httptest.Server) {
+	f := &fakeNitro{objects: map[string][]map[string]interface{}{}}
+	return f, httptest.NewServer(f)
+}
+
+func (f *fakeNitro) reply(w http.ResponseWriter, status int, errorCode int, message string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"errorcode": errorCode, "message": message})
+}
+
+func (f *fakeNitro) find(resourceType string, args map[string]string) int {
+	for i, object := range f.objects[resourceType] {
+		matches := true
+		for k, v := range args {
+			if fmt.Sprint(object[k]) != v {
+				matches = false
+			}
+		}
+		if matches {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeNitro) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/nitro/v1/config/"), "/")
+	resourceType := parts[0]
+	name := ""
+	if len(parts) > 1 {
+		name = parts[1]
+	}
+
+	keys, ok := fakeNitroKeys[resourceType]
+	if !ok {
+		f.reply(w, http.StatusBadRequest, 1, "Unsupported resource type "+resourceType)
+		return
+	}
+	isBinding := len(keys) > 1
+
+	var object map[string]interface{}
+	if r.Method == "POST" || r.Method == "PUT" {
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			f.reply(w, http.StatusBadRequest, 1, err.Error())
+			return
+		}
+		object = body[resourceType]
+	}
+
+	switch {
+	case r.Method == "POST" && r.URL.Query().Get("action") != "":
+		f.actions = append(f.actions, resourceType+":"+r.URL.Query().Get("action"))
+		f.reply(w, http.StatusOK, 0, "Done")
+
+	case r.Method == "POST" || (r.Method == "PUT" && isBinding):
+		args := map[string]string{}
+		for _, key := range keys {
+			args[key] = fmt.Sprint(object[key])
+		}
+		if f.find(resourceType, args) >= 0 {
+			f.reply(w, http.StatusConflict, 273, "Resource already exists")
+			return
+		}
+		f.objects[resourceType] = append(f.objects[resourceType], object)
+		f.reply(w, http.StatusCreated, 0, "Done")
+
+	case r.Method == "PUT":
+		i := f.find(resourceType, map[string]string{keys[0]: fmt.Sprint(object[keys[0]])})
+		if i < 0 {
+			f.reply(w, http.StatusNotFound, 258, "No such resource")
+			return
+		}
+		for k, v := range object {
+			f.objects[resourceType][i][k] = v
+		}
+		f.reply(w, http.StatusOK, 0, "Done")
+
+	case r.Method == "GET":
+		result := make([]map[string]interface{}, 0)
+		for _, object := range f.objects[resourceType] {
+			if name == "" || object[keys[0]] == name {
+				result = append(result, object)
+			}
+		}
+		if len(result) == 0 && !isBinding {
+			f.reply(w, http.StatusNotFound, 258, "No such resource")
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"errorcode": 0, "message": "Done", resourceType: result})
+
+	case r.Method == "DELETE":
+		args := map[string]string{keys[0]: name}
+		for _, arg := range strings.Split(r.URL.Query().Get("args"), ",") {
+			if kv := strings.SplitN(arg, ":", 2); len(kv) == 2 && isBinding {
+				args[kv[0]] = kv[1]
+			}
+		}
+
+		i := f.find(resourceType, args)
+		if i < 0 {
+			f.reply(w, http.StatusNotFound, 258, "No such resource")
+			return
+		}
+		f.objects[resourceType] = append(f.objects[resourceType][:i], f.objects[resourceType][i+1:]...)
+
+		// Deleting a resource deletes its bindings
+		if !isBinding {
+			for bindingType, bindingKeys := range fakeNitroKeys {
+				if len(bindingKeys) == 1 {
+					continue
+				}
+				kept := make([]map[string]interface{}, 0)
+				for _, binding := range f.objects[bindingType] {
+					if binding[bindingKeys[0]] != name && binding[bindingKeys[1]] != name {
+						kept = append(kept, binding)
+					}
+				}
+				f.objects[bindingType] = kept
+			}
+		}
+		f.reply(w, http.StatusOK, 0, "Done")
+	}
+}
+
+func (f *fakeNitro) count(resourceType string) int {
+	f.Lock()
+	defer f.Unlock()
+	return len(f.objects[resourceType])
+}
+
+// countActions returns the number of times an action, such as "nsconfig:save", was run
+func (f *fakeNitro) countActions(action string) int {
+	f.Lock()
+	defer f.Unlock()
+	count := 0
+	for _, a := range f.actions {
+		if a == action {
+			count++
+		}
+	}
+	return count
+}
+
+func TestNitroVipLifecycle(t *testing.T) {
+	f, server := newFakeNitro()
+	defer server.Close()
+	client := newNitroClient(server.URL, "root", "secret")
+
+	vip := nitroVip{
+		Vserver: nitroVserver{
+			Name:            "web",
+			ServiceType:     "SSL",
+			IPv46:           "10.0.0.10",
+			Port:            443,
+			LbMethod:        nitroLbMethod("lc"),
+			PersistenceType: "COOKIEINSERT",
+			Timeout:         10,
+		},
+		CertKey: &nitroCertKey{
+			Name:         nitroCertKeyName(42),
+			Certificate:  "CERT",
+			PrivateKey:   "KEY",
+			Intermediate: "CA",
+		},
+	}
+
+	if err := createNitroVip(client, vip); err != nil {
+		t.Fatalf("createNitroVip: %s", err)
+	}
+
+	read, err := readNitroVip(client, "web")
+	if err != nil {
+		t.Fatalf("readNitroVip: %s", err)
+	}
+	if read.Vserver.LbMethod != "LEASTCONNECTION" || read.Vserver.PersistenceType != "COOKIEINSERT" ||
+		read.Vserver.Timeout != 10 || read.Vserver.Port != 443 {
+		t.Errorf("Unexpected virtual server: %+v", read.Vserver)
+	}
+	if read.CertKey == nil || parseNitroCertKeyName(read.CertKey.Name) != 42 {
+		t.Errorf("Expected certificate 42, got %+v", read.CertKey)
+	}
+
+	if f.count("systemfile") != 3 || f.count("sslcertkey") != 2 {
+		t.Errorf("Expected the certificate, key and intermediate certificate to be installed")
+	}
+	for _, file := range f.objects["systemfile"] {
+		if file["filename"] == "sl-cert-42.key" {
+			content, _ := base64.StdEncoding.DecodeString(file["filecontent"].(string))
+			if string(content) != "KEY" {
+				t.Errorf("Unexpected key content: %s", content)
+			}
+		}
+	}
+	if f.countActions("sslcertkey:link") != 1 {
+		t.Errorf("Expected the intermediate certificate to be linked, got %v", f.actions)
+	}
+	if f.countActions("nsconfig:save") != 1 {
+		t.Errorf("Expected the configuration to be saved, got %v", f.actions)
+	}
+
+	// A second VIP with the same certificate reuses it
+	second := vip
+	second.Vserver.Name = "web2"
+	if err := createNitroVip(client, second); err != nil {
+		t.Fatalf("createNitroVip with an installed certificate: %s", err)
+	}
+
+	updated := vip
+	updated.Vserver.LbMethod = nitroLbMethod("rr")
+	updated.Vserver.PersistenceType = "NONE"
+	updated.CertKey = &nitroCertKey{Name: nitroCertKeyName(43), Certificate: "CERT2", PrivateKey: "KEY2"}
+	if err := updateNitroVip(client, read, updated); err != nil {
+		t.Fatalf("updateNitroVip: %s", err)
+	}
+
+	read, err = readNitroVip(client, "web")
+	if err != nil {
+		t.Fatalf("readNitroVip: %s", err)
+	}
+	if read.Vserver.LbMethod != "ROUNDROBIN" || read.Vserver.PersistenceType != "NONE" {
+		t.Errorf("Unexpected virtual server after update: %+v", read.Vserver)
+	}
+	if read.CertKey == nil || parseNitroCertKeyName(read.CertKey.Name) != 43 {
+		t.Errorf("Expected certificate 43 after update, got %+v", read.CertKey)
+	}
+	if f.countActions("nsconfig:save") != 3 {
+		t.Errorf("Expected the configuration to be saved after the update, got %v", f.actions)
+	}
+
+	if err := deleteNitroVip(client, read); err != nil {
+		t.Fatalf("deleteNitroVip: %s", err)
+	}
+	if _, err := readNitroVip(client, "web"); !isNitroNotFound(err) {
+		t.Errorf("Expected the VIP to be deleted, got %v", err)
+	}
+	if f.countActions("nsconfig:save") != 4 {
+		t.Errorf("Expected the configuration to be saved after the deletion, got %v", f.actions)
+	}
+
+	// Deleting a deleted VIP succeeds
+	if err := deleteNitroVip(client, read); err != nil {
+		t.Errorf("deleteNitroVip of a deleted VIP: %s", err)
+	}
+}
+
+func TestNitroContentSwitching(t *testing.T) {
+	f, server := newFakeNitro()
+	defer server.Close()
+	client := newNitroClient(server.URL, "root", "secret")
+
+	for _, name := range []string{"api", "static"} {
+		err := createNitroVip(client, nitroVip{
+			Vserver: nitroVserver{Name: name, ServiceType: "HTTP", IPv46: "0.0.0.0", LbMethod: "ROUNDROBIN"},
+		})
+		if err != nil {
+			t.Fatalf("createNitroVip %s: %s", name, err)
+		}
+	}
+
+	vip := nitroVip{
+		Vserver: nitroVserver{Name: "front", ServiceType: "HTTP", IPv46: "10.0.0.10", Port: 80},
+		Rules: []nitroCsRule{
+			{Name: "api-rule", Expression: `HTTP.REQ.URL.STARTSWITH("/api")`, Target: "api", Priority: 10},
+			{Name: "static-rule", Expression: `HTTP.REQ.URL.STARTSWITH("/static")`, Target: "static", Priority: 20},
+		},
+	}
+
+	if err := createNitroVip(client, vip); err != nil {
+		t.Fatalf("createNitroVip: %s", err)
+	}
+
+	read, err := readNitroVip(client, "front")
+	if err != nil {
+		t.Fatalf("readNitroVip: %s", err)
+	}
+	if read.vserverType() != "csvserver" || len(read.Rules) != 2 || read.Rules[1] != vip.Rules[1] {
+		t.Errorf("Unexpected content switching VIP: %+v", read)
+	}
+
+	updated := vip
+	updated.Rules = []nitroCsRule{
+		{Name: "api-rule", Expression: `HTTP.REQ.URL.STARTSWITH("/v2")`, Target: "api", Priority: 10},
+	}
+	if err := updateNitroVip(client, read, updated); err != nil {
+		t.Fatalf("updateNitroVip: %s", err)
+	}
+
+	read, err = readNitroVip(client, "front")
+	if err != nil {
+		t.Fatalf("readNitroVip: %s", err)
+	}
+	if len(read.Rules) != 1 || read.Rules[0] != updated.Rules[0] {
+		t.Errorf("Unexpected rules after update: %+v", read.Rules)
+	}
+	if f.count("cspolicy") != 1 {
+		t.Errorf("Expected the removed rule's policy to be deleted")
+	}
+
+	if err := deleteNitroVip(client, read); err != nil {
+		t.Fatalf("deleteNitroVip: %s", err)
+	}
+	if f.count("cspolicy") != 0 || f.count("csvserver") != 0 {
+		t.Errorf("Expected the content switching VIP and its policies to be deleted")
+	}
+}
+
+func TestNitroServiceLifecycle(t *testing.T) {
+	f, server := newFakeNitro()
+	defer server.Close()
+	client := newNitroClient(server.URL, "root", "secret")
+
+	vip := nitroVip{
+		Vserver: nitroVserver{Name: "web", ServiceType: "HTTP", IPv46: "10.0.0.10", Port: 80, LbMethod: "ROUNDROBIN"},
+	}
+	if err := createNitroVip(client, vip); err != nil {
+		t.Fatalf("createNitroVip: %s", err)
+	}
+
+	lbService := nitroLbService{
+		Service: nitroService{Name: "app1", Ip: "10.0.1.1", Port: 8080, ServiceType: "HTTP", MaxClient: 100},
+		VipName: "web",
+		Weight:  20,
+		Monitor: nitroMonitor{
+			MonitorName: nitroServiceMonitorName("app1"),
+			Type:        "HTTP-ECV",
+			HttpRequest: "GET /health",
+			Recv:        "OK",
+			Interval:    10,
+			RespTimeout: 3,
+		},
+	}
+
+	if err := createNitroService(client, lbService); err != nil {
+		t.Fatalf("createNitroService: %s", err)
+	}
+	if f.countActions("nsconfig:save") != 2 {
+		t.Errorf("Expected the configuration to be saved after creating the service, got %v", f.actions)
+	}
+
+	read, err := readNitroService(client, "web", "app1")
+	if err != nil {
+		t.Fatalf("readNitroService: %s", err)
+	}
+	if read.Weight != 20 || read.Service.MaxClient != 100 || read.Monitor.Recv != "OK" || read.Monitor.Interval != 10 {
+		t.Errorf("Unexpected service: %+v", read)
+	}
+
+	updated := lbService
+	updated.Weight = 50
+	updated.Service.MaxClient = 200
+	updated.Monitor = nitroMonitor{MonitorName: nitroBuiltinMonitor("TCP")}
+	if err := updateNitroService(client, read, updated); err != nil {
+		t.Fatalf("updateNitroService: %s", err)
+	}
+
+	read, err = readNitroService(client, "web", "app1")
+	if err != nil {
+		t.Fatalf("readNitroService: %s", err)
+	}
+	if read.Weight != 50 || read.Service.MaxClient != 200 || read.Monitor.MonitorName != "tcp" ||
+		lbVpxHealthCheck(read.Monitor.MonitorName) != "TCP" {
+		t.Errorf("Unexpected service after update: %+v", read)
+	}
+	if f.count("lbmonitor") != 0 {
+		t.Errorf("Expected the custom monitor to be deleted")
+	}
+	if f.countActions("nsconfig:save") != 3 {
+		t.Errorf("Expected the configuration to be saved after updating the service, got %v", f.actions)
+	}
+
+	// Adding SSL offload recreates the VIP, which keeps its services
+	ssl := vip
+	ssl.Vserver.ServiceType = "SSL"
+	ssl.CertKey = &nitroCertKey{Name: nitroCertKeyName(42), Certificate: "CERT", PrivateKey: "KEY"}
+	if err := updateNitroVip(client, vip, ssl); err != nil {
+		t.Fatalf("updateNitroVip: %s", err)
+	}
+	if read, err = readNitroService(client, "web", "app1"); err != nil || read.Weight != 50 {
+		t.Errorf("Expected the service to stay bound to the recreated VIP, got %+v: %v", read, err)
+	}
+
+	// Services can't be bound to a content switching VIP
+	cs := ssl
+	cs.Rules = []nitroCsRule{{Name: "rule", Expression: "true", Target: "other", Priority: 1}}
+	if err := updateNitroVip(client, ssl, cs); err == nil {
+		t.Errorf("Expected an error adding content switching rules to a VIP with services")
+	}
+
+	if err := deleteNitroService(client, read); err != nil {
+		t.Fatalf("deleteNitroService: %s", err)
+	}
+	if _, err := readNitroService(client, "web", "app1"); !isNitroNotFound(err) {
+		t.Errorf("Expected the service to be deleted, got %v", err)
+	}
+	if f.count("lbvserver_service_binding") != 0 {
+		t.Errorf("Expected the service binding to be deleted")
+	}
+	if f.actions[len(f.actions)-1] != "nsconfig:save" {
+		t.Errorf("Expected the configuration to be saved after deleting the service, got %v", f.actions)
+	}
+}
+
+func TestNitroNames(t *testing.T) {
+	if parseNitroCertKeyName(nitroCertKeyName(7)) != 7 || parseNitroCertKeyName("mycert") != 0 ||
+		parseNitroCertKeyName("sl-cert-7x") != 0 {
+		t.Errorf("Unexpected certificate IDs of cert key names")
+	}
+
+	if serviceType, _ := nitroVserverServiceType("tcp", true); serviceType != "SSL_TCP" {
+		t.Errorf("Expected SSL_TCP, got %s", serviceType)
+	}
+	if _, err := nitroVserverServiceType("UDP", true); err == nil {
+		t.Errorf("Expected an error for SSL offload of UDP")
+	}
+	if lbVpxVipType("SSL") != "HTTP" || lbVpxVipType("DNS") != "DNS" {
+		t.Errorf("Unexpected VIP types of NITRO service types")
+	}
+
+	if nitroLbMethod("lc") != "LEASTCONNECTION" || nitroLbMethod("leastresponsetime") != "LEASTRESPONSETIME" {
+		t.Errorf("Unexpected NITRO load balancing methods")
+	}
+	if nitroBuiltinMonitor("ICMP") != "ping" || lbVpxHealthCheck("ping") != "ICMP" {
+		t.Errorf("Unexpected built-in monitors")
+	}
+}
@@ -0,0 +1,262 @@
+package softlayer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// nitroClient calls the NITRO REST API of a NetScaler VPX over its management IP address
+type nitroClient struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// nitroResponse holds the status fields which are part of every NITRO response
+type nitroResponse struct {
+	ErrorCode int    `json:"errorcode"`
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+}
+
+type nitroHaNode struct {
+	Id        string `json:"id"`
+	IpAddress string `json:"ipaddress"`
+	State     string `json:"state,omitempty"`
+	HaStatus  string `json:"hastatus,omitempty"`
+}
+
+// nitroProtocolEnv is the environment variable which selects the protocol of the NITRO API. It is https
+// unless it is set to http, which sends the credentials of the VPX in clear text.
+const nitroProtocolEnv = "SOFTLAYER_NITRO_PROTOCOL"
+
+// newNitroClient returns a client for the NITRO API at address, which is either the management IP address
+// of a VPX or a full URL such as http://127.0.0.1:8080.
+func newNitroClient(address, username, password string) *nitroClient {
+	baseURL := address
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		protocol := "https"
+		if strings.ToLower(os.Getenv(nitroProtocolEnv)) == "http" {
+			protocol = "http"
+		}
+		baseURL = protocol + "://" + address
+	}
+
+	return &nitroClient{
+		baseURL:  strings.TrimSuffix(baseURL, "/") + "/nitro/v1",
+		username: username,
+		password: password,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+			// The management interface of a VPX has a self-signed certificate
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+}
+
+// getVpxNitroClient returns a NITRO client for a VPX, using the management IP address and the admin
+// credentials reported by SoftLayer.
+func getVpxNitroClient(sess *session.Session, nadcId int) (*nitroClient, error) {
+	nadc, err := services.GetNetworkApplicationDeliveryControllerService(sess).
+		Id(nadcId).
+		Mask("id,managementIpAddress,password[username,password]").
+		GetObject()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving network application delivery controller: %s", err)
+	}
+
+	if nadc.ManagementIpAddress == nil || nadc.Password == nil {
+		return nil, fmt.Errorf("No management IP address or credentials for Netscaler VPX ID: %d", nadcId)
+	}
+
+	return newNitroClient(*nadc.ManagementIpAddress,
+		sl.Get(nadc.Password.Username, "").(string), sl.Get(nadc.Password.Password, "").(string)), nil
+}
+
+// do sends a NITRO request. The body is wrapped in an object keyed by the resource type, as NITRO expects.
+func (c *nitroClient) do(method, resourceType, name, query string, body interface{}, result interface{}) error {
+	url := c.baseURL + "/config/" + resourceType
+	if name != "" {
+		url += "/" + name
+	}
+	if query != "" {
+		url += "?" + query
+	}
+
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(map[string]interface{}{resourceType: body})
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-NITRO-USER", c.username)
+	req.Header.Set("X-NITRO-PASS", c.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	log.Printf("[DEBUG] NITRO request: %s %s", method, url)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error calling NITRO API %s %s: %s", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var status nitroResponse
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &status); err != nil {
+			return fmt.Errorf("Error parsing NITRO response of %s %s: %s", method, url, err)
+		}
+	}
+
+	if resp.StatusCode >= 300 || status.ErrorCode != 0 {
+		return &nitroError{StatusCode: resp.StatusCode, ErrorCode: status.ErrorCode, Message: status.Message}
+	}
+
+	if result != nil && len(respBody) > 0 {
+		return json.Unmarshal(respBody, result)
+	}
+
+	return nil
+}
+
+// nitroError is an error returned by the NITRO API
+type nitroError struct {
+	StatusCode int
+	ErrorCode  int
+	Message    string
+}
+
+func (e *nitroError) Error() string {
+	return fmt.Sprintf("NITRO error %d (HTTP %d): %s", e.ErrorCode, e.StatusCode, e.Message)
+}
+
+// isNitroNotFound returns whether a NITRO error reports a missing resource
+func isNitroNotFound(err error) bool {
+	if nErr, ok := err.(*nitroError); ok {
+		return nErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// nitroInt is an integer which NITRO may return as a JSON string
+type nitroInt int
+
+func (n *nitroInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+
+	v, err := strconv.Atoi(s)
+	*n = nitroInt(v)
+	return err
+}
+
+// add creates a NITRO resource
+func (c *nitroClient) add(resourceType string, object interface{}) error {
+	return c.do("POST", resourceType, "", "", object, nil)
+}
+
+// update changes the properties of a NITRO resource, which is identified by the key property of object
+func (c *nitroClient) update(resourceType string, object interface{}) error {
+	return c.do("PUT", resourceType, "", "", object, nil)
+}
+
+// get retrieves the NITRO resources of a type into result, which must be a pointer to a slice. If name is
+// empty all of the resources of the type are retrieved, else the one with the name or, for a binding type,
+// the bindings of the named resource.
+func (c *nitroClient) get(resourceType, name string, result interface{}) error {
+	var response map[string]json.RawMessage
+	err := c.do("GET", resourceType, name, "", nil, &response)
+	if err != nil {
+		return err
+	}
+
+	if raw, ok := response[resourceType]; ok {
+		return json.Unmarshal(raw, result)
+	}
+
+	return nil
+}
+
+// remove deletes a NITRO resource. args holds the additional arguments which identify some resource types,
+// such as "type:HTTP" for a monitor.
+func (c *nitroClient) remove(resourceType, name, args string) error {
+	query := ""
+	if args != "" {
+		query = "args=" + args
+	}
+	return c.do("DELETE", resourceType, name, query, nil, nil)
+}
+
+// bind creates a binding between two NITRO resources
+func (c *nitroClient) bind(bindingType string, binding interface{}) error {
+	return c.do("PUT", bindingType, "", "", binding, nil)
+}
+
+// unbind deletes a binding of the named resource, identified by args
+func (c *nitroClient) unbind(bindingType, name, args string) error {
+	return c.remove(bindingType, name, args)
+}
+
+// action runs an action, such as "link", on a NITRO resource
+func (c *nitroClient) action(resourceType, action string, object interface{}) error {
+	return c.do("POST", resourceType, "", "action="+action, object, nil)
+}
+
+func (c *nitroClient) addHaNode(id int, ipAddress string) error {
+	return c.add("hanode", map[string]interface{}{
+		"id":        id,
+		"ipaddress": ipAddress,
+	})
+}
+
+func (c *nitroClient) getHaNodes() ([]nitroHaNode, error) {
+	var haNodes []nitroHaNode
+	err := c.get("hanode", "", &haNodes)
+	return haNodes, err
+}
+
+// saveConfig saves the running configuration, so that it survives a reboot of the VPX
+func (c *nitroClient) saveConfig() error {
+	return c.action("nsconfig", "save", map[string]interface{}{})
+}
+
+// setRpcNodePassword sets the password used by the RPC node of ipAddress, which must match on both nodes
+// of a HA pair
+func (c *nitroClient) setRpcNodePassword(ipAddress, password string) error {
+	return c.update("nsrpcnode", map[string]interface{}{
+		"ipaddress": ipAddress,
+		"password":  password,
+	})
+}
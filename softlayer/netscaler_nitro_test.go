@@ -0,0 +1,126 @@
+package softlayer
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+// newTestNitroServer returns a NITRO stand-in which records the requests it receives and replies with
+// the given responses, keyed by method and path
+func newTestNitroServer(responses map[string]string, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-NITRO-USER") != "root" || r.Header.Get("X-NITRO-PASS") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errorcode": 354, "message": "Invalid username or password", "severity": "ERROR"}`))
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		key := r.Method + " " + r.URL.RequestURI()
+		*requests = append(*requests, key+" "+string(body))
+
+		response, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorcode": 258, "message": "No such resource", "severity": "ERROR"}`))
+			return
+		}
+		w.Write([]byte(response))
+	}))
+}
+
+func TestNitroClientHaNodes(t *testing.T) {
+	var requests []string
+	server := newTestNitroServer(map[string]string{
+		"POST /nitro/v1/config/hanode":               `{"errorcode": 0, "message": "Done"}`,
+		"POST /nitro/v1/config/nsconfig?action=save": `{"errorcode": 0, "message": "Done"}`,
+		"PUT /nitro/v1/config/nsrpcnode":             `{"errorcode": 0, "message": "Done"}`,
+		"GET /nitro/v1/config/hanode": `{"errorcode": 0, "message": "Done", "hanode": [` +
+			`{"id": "0", "ipaddress": "10.0.0.1", "state": "Primary", "hastatus": "UP"},` +
+			`{"id": "1", "ipaddress": "10.0.0.2", "state": "Secondary", "hastatus": "UP"}]}`,
+	}, &requests)
+	defer server.Close()
+
+	client := newNitroClient(server.URL, "root", "secret")
+
+	if err := client.setRpcNodePassword("10.0.0.2", "shared"); err != nil {
+		t.Fatalf("setRpcNodePassword: %s", err)
+	}
+	if err := client.addHaNode(1, "10.0.0.2"); err != nil {
+		t.Fatalf("addHaNode: %s", err)
+	}
+	if err := client.saveConfig(); err != nil {
+		t.Fatalf("saveConfig: %s", err)
+	}
+
+	expected := []string{
+		`PUT /nitro/v1/config/nsrpcnode {"nsrpcnode":{"ipaddress":"10.0.0.2","password":"shared"}}`,
+		`POST /nitro/v1/config/hanode {"hanode":{"id":1,"ipaddress":"10.0.0.2"}}`,
+		`POST /nitro/v1/config/nsconfig?action=save {"nsconfig":{}}`,
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("Expected %d requests, got %v", len(expected), requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Errorf("Expected request %s, got %s", expected[i], requests[i])
+		}
+	}
+
+	nodes, err := client.getHaNodes()
+	if err != nil {
+		t.Fatalf("getHaNodes: %s", err)
+	}
+	if len(nodes) != 2 || nodes[1].IpAddress != "10.0.0.2" || nodes[1].State != "Secondary" {
+		t.Errorf("Unexpected HA nodes: %+v", nodes)
+	}
+}
+
+func TestNitroClientErrors(t *testing.T) {
+	var requests []string
+	server := newTestNitroServer(map[string]string{
+		"POST /nitro/v1/config/hanode": `{"errorcode": 273, "message": "Resource already exists", "severity": "ERROR"}`,
+	}, &requests)
+	defer server.Close()
+
+	err := newNitroClient(server.URL, "root", "secret").addHaNode(1, "10.0.0.2")
+	if nErr, ok := err.(*nitroError); !ok || nErr.ErrorCode != 273 {
+		t.Errorf("Expected NITRO error 273, got %v", err)
+	}
+
+	_, err = newNitroClient(server.URL, "root", "secret").getHaNodes()
+	if !isNitroNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	err = newNitroClient(server.URL, "root", "wrong").saveConfig()
+	if nErr, ok := err.(*nitroError); !ok || nErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestNewNitroClientAddress(t *testing.T) {
+	defer os.Setenv(nitroProtocolEnv, os.Getenv(nitroProtocolEnv))
+	os.Unsetenv(nitroProtocolEnv)
+
+	cases := map[string]string{
+		"10.0.0.1":              "https://10.0.0.1/nitro/v1",
+		"https://10.0.0.1/":     "https://10.0.0.1/nitro/v1",
+		"http://127.0.0.1:8080": "http://127.0.0.1:8080/nitro/v1",
+	}
+
+	for address, expected := range cases {
+		if baseURL := newNitroClient(address, "", "").baseURL; baseURL != expected {
+			t.Errorf("Expected base URL %s for %s, got %s", expected, address, baseURL)
+		}
+	}
+
+	// Plain http must be requested explicitly
+	os.Setenv(nitroProtocolEnv, "http")
+	if baseURL := newNitroClient("10.0.0.1", "", "").baseURL; baseURL != "http://10.0.0.1/nitro/v1" {
+		t.Errorf("Expected an http base URL, got %s", baseURL)
+	}
+}
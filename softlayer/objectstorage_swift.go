@@ -0,0 +1,308 @@
+package softlayer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	swiftContainerMetaPrefix = "X-Container-Meta-"
+	swiftObjectMetaPrefix    = "X-Object-Meta-"
+)
+
+// swiftError is returned by swiftClient when the Swift API answers with an unexpected status
+type swiftError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	Path       string
+}
+
+func (e swiftError) Error() string {
+	return fmt.Sprintf("Swift %s %s: %s", e.Method, e.Path, e.Status)
+}
+
+func isSwiftNotFound(err error) bool {
+	apiErr, ok := err.(swiftError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// swiftClient is a minimal client of the OpenStack Swift API, authenticated with the v1 auth protocol
+// used by SoftLayer object storage.
+type swiftClient struct {
+	authURL  string
+	username string
+	apiKey   string
+
+	storageURL string
+	token      string
+
+	httpClient *http.Client
+}
+
+func newSwiftClient(authURL, username, apiKey string) *swiftClient {
+	return &swiftClient{
+		authURL:    authURL,
+		username:   username,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// getObjectStorageSwiftClient returns a Swift client authenticated with the credentials of an object
+// storage account. The auth endpoint of the datacenter is used unless authURL is set.
+func getObjectStorageSwiftClient(sess *session.Session, accountName, datacenter, authURL string) (*swiftClient, error) {
+	objectStorageAccount, found, err := getObjectStorageAccount(sess, accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("Object storage account %s not found", accountName)
+	}
+
+	username, apiKey, err := getObjectStorageCredentials(sess, *objectStorageAccount.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if authURL == "" {
+		connectionInfo, err := services.GetNetworkStorageService(sess).Id(*objectStorageAccount.Id).
+			GetObjectStorageConnectionInformation()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range connectionInfo {
+			if sl.Get(info.DatacenterShortName, "").(string) == datacenter {
+				authURL = sl.Get(info.PublicEndpoint, "").(string)
+				break
+			}
+		}
+
+		if authURL == "" {
+			return nil, fmt.Errorf("No object storage endpoint found in datacenter %s", datacenter)
+		}
+	}
+
+	return newSwiftClient(authURL, username, apiKey), nil
+}
+
+func (c *swiftClient) authenticate() error {
+	req, err := http.NewRequest("GET", c.authURL, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Auth-User", c.username)
+	req.Header.Set("X-Auth-Key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Error authenticating to %s: %s", c.authURL, resp.Status)
+	}
+
+	c.token = resp.Header.Get("X-Auth-Token")
+	c.storageURL = strings.TrimRight(resp.Header.Get("X-Storage-Url"), "/")
+
+	if c.token == "" || c.storageURL == "" {
+		return fmt.Errorf("Error authenticating to %s: no token or storage URL returned", c.authURL)
+	}
+
+	return nil
+}
+
+// do sends a request to the storage URL of the account. The client authenticates on the first request
+// and once more when its token has expired. Bodies must be seekable so they can be sent again.
+func (c *swiftClient) do(method, path string, query url.Values, headers http.Header, body io.ReadSeeker) (
+	*http.Response, error) {
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if c.token == "" || attempt > 0 {
+			if err := c.authenticate(); err != nil {
+				return nil, err
+			}
+		}
+
+		target := c.storageURL + (&url.URL{Path: "/" + path}).EscapedPath()
+		if len(query) > 0 {
+			target += "?" + query.Encode()
+		}
+
+		var reqBody io.Reader
+		if body != nil {
+			if _, err := body.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+			reqBody = body
+		}
+
+		req, err := http.NewRequest(method, target, reqBody)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range headers {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		req.Header.Set("X-Auth-Token", c.token)
+
+		log.Printf("[DEBUG] Swift request: %s %s", method, path)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			resp.Body.Close()
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			resp.Body.Close()
+			return nil, swiftError{StatusCode: resp.StatusCode, Status: resp.Status, Method: method, Path: path}
+		}
+
+		return resp, nil
+	}
+
+	return nil, fmt.Errorf("Swift %s %s: unauthorized", method, path)
+}
+
+// doAndClose sends a request whose response body is not needed and returns the response headers
+func (c *swiftClient) doAndClose(method, path string, headers http.Header, body io.ReadSeeker) (http.Header, error) {
+	resp, err := c.do(method, path, nil, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.Header, nil
+}
+
+func (c *swiftClient) createContainer(container string, headers http.Header) error {
+	_, err := c.doAndClose("PUT", container, headers, nil)
+	return err
+}
+
+func (c *swiftClient) updateContainer(container string, headers http.Header) error {
+	_, err := c.doAndClose("POST", container, headers, nil)
+	return err
+}
+
+func (c *swiftClient) headContainer(container string) (http.Header, error) {
+	return c.doAndClose("HEAD", container, nil, nil)
+}
+
+func (c *swiftClient) deleteContainer(container string) error {
+	_, err := c.doAndClose("DELETE", container, nil, nil)
+	return err
+}
+
+// listObjects returns the names of all the objects of a container, following the pagination markers
+func (c *swiftClient) listObjects(container string) ([]string, error) {
+	names := []string{}
+	marker := ""
+
+	for {
+		query := url.Values{}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		resp, err := c.do("GET", container, query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page := []string{}
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if name := scanner.Text(); name != "" {
+				page = append(page, name)
+			}
+		}
+		resp.Body.Close()
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 {
+			return names, nil
+		}
+
+		names = append(names, page...)
+		marker = page[len(page)-1]
+	}
+}
+
+// putObject uploads an object and returns its ETag
+func (c *swiftClient) putObject(container, object string, headers http.Header, body io.ReadSeeker) (string, error) {
+	respHeaders, err := c.doAndClose("PUT", container+"/"+object, headers, body)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(respHeaders.Get("Etag"), `"`), nil
+}
+
+func (c *swiftClient) headObject(container, object string) (http.Header, error) {
+	return c.doAndClose("HEAD", container+"/"+object, nil, nil)
+}
+
+func (c *swiftClient) deleteObject(container, object string) error {
+	_, err := c.doAndClose("DELETE", container+"/"+object, nil, nil)
+	return err
+}
+
+// swiftMetadataHeaders converts metadata to headers with the given prefix
+func swiftMetadataHeaders(prefix string, metadata map[string]interface{}, headers http.Header) {
+	for key, value := range metadata {
+		headers.Set(prefix+key, value.(string))
+	}
+}
+
+// readSwiftMetadata extracts the metadata with the given prefix from headers. Header names are
+// case-insensitive, so the keys are matched against the configured metadata to keep their case.
+func readSwiftMetadata(prefix string, headers http.Header, configured map[string]interface{}) map[string]interface{} {
+	metadata := map[string]interface{}{}
+
+	for name := range headers {
+		if !strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+			continue
+		}
+
+		key := strings.ToLower(name[len(prefix):])
+		for configuredKey := range configured {
+			if strings.EqualFold(configuredKey, key) {
+				key = configuredKey
+				break
+			}
+		}
+
+		metadata[key] = headers.Get(name)
+	}
+
+	return metadata
+}
@@ -0,0 +1,399 @@
+package softlayer
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testSwiftServer is an in-memory stand-in for a Swift object storage cluster. It implements the v1 auth
+// protocol and the container and object operations used by swiftClient.
+type testSwiftServer struct {
+	*httptest.Server
+
+	username string
+	apiKey   string
+
+	mu         sync.Mutex
+	token      int
+	containers map[string]*testSwiftContainer
+}
+
+type testSwiftContainer struct {
+	headers http.Header
+	objects map[string]*testSwiftObject
+}
+
+type testSwiftObject struct {
+	headers http.Header
+	content []byte
+}
+
+func newTestSwiftServer(username, apiKey string) *testSwiftServer {
+	s := &testSwiftServer{
+		username:   username,
+		apiKey:     apiKey,
+		containers: map[string]*testSwiftContainer{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *testSwiftServer) authURL() string {
+	return s.URL + "/auth/v1.0"
+}
+
+// expireToken invalidates the current token, as when it expires on a real cluster
+func (s *testSwiftServer) expireToken() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token++
+}
+
+func (s *testSwiftServer) currentToken() string {
+	return fmt.Sprintf("AUTH_tk%d", s.token)
+}
+
+func (s *testSwiftServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/auth/v1.0" {
+		if r.Header.Get("X-Auth-User") != s.username || r.Header.Get("X-Auth-Key") != s.apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Auth-Token", s.currentToken())
+		w.Header().Set("X-Storage-Url", s.URL+"/v1/AUTH_test")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if r.Header.Get("X-Auth-Token") != s.currentToken() {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/AUTH_test/")
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) == 1 {
+		s.handleContainer(w, r, parts[0])
+	} else {
+		s.handleObject(w, r, parts[0], parts[1])
+	}
+}
+
+func (s *testSwiftServer) handleContainer(w http.ResponseWriter, r *http.Request, name string) {
+	container, ok := s.containers[name]
+
+	switch r.Method {
+	case "PUT":
+		if !ok {
+			container = &testSwiftContainer{headers: http.Header{}, objects: map[string]*testSwiftObject{}}
+			s.containers[name] = container
+		}
+		updateTestSwiftHeaders(container.headers, r.Header, "Container")
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case "POST":
+		updateTestSwiftHeaders(container.headers, r.Header, "Container")
+		w.WriteHeader(http.StatusNoContent)
+	case "HEAD":
+		for key, values := range container.headers {
+			w.Header()[key] = values
+		}
+		bytes := 0
+		for _, object := range container.objects {
+			bytes += len(object.content)
+		}
+		w.Header().Set("X-Container-Object-Count", fmt.Sprintf("%d", len(container.objects)))
+		w.Header().Set("X-Container-Bytes-Used", fmt.Sprintf("%d", bytes))
+		w.WriteHeader(http.StatusNoContent)
+	case "GET":
+		names := []string{}
+		marker := r.URL.Query().Get("marker")
+		for objectName := range container.objects {
+			if objectName > marker {
+				names = append(names, objectName)
+			}
+		}
+		sort.Strings(names)
+		// Paginate with small pages to exercise the markers
+		if len(names) > 2 {
+			names = names[:2]
+		}
+		if len(names) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, strings.Join(names, "\n")+"\n")
+	case "DELETE":
+		if len(container.objects) > 0 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		delete(s.containers, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *testSwiftServer) handleObject(w http.ResponseWriter, r *http.Request, containerName, name string) {
+	container, ok := s.containers[containerName]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	object, ok := container.objects[name]
+
+	switch r.Method {
+	case "PUT":
+		content, _ := ioutil.ReadAll(r.Body)
+		hash := md5.Sum(content)
+		object = &testSwiftObject{headers: http.Header{}, content: content}
+		updateTestSwiftHeaders(object.headers, r.Header, "Object")
+		object.headers.Set("Etag", hex.EncodeToString(hash[:]))
+		if contentType := r.Header.Get("Content-Type"); contentType != "" {
+			object.headers.Set("Content-Type", contentType)
+		} else {
+			object.headers.Set("Content-Type", "application/octet-stream")
+		}
+		container.objects[name] = object
+		w.Header().Set("Etag", object.headers.Get("Etag"))
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case "HEAD":
+		for key, values := range object.headers {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(object.content)))
+		w.WriteHeader(http.StatusOK)
+	case "DELETE":
+		delete(container.objects, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// updateTestSwiftHeaders applies the metadata, ACL, CDN and removal headers of a request
+func updateTestSwiftHeaders(stored, request http.Header, kind string) {
+	for name, values := range request {
+		switch {
+		case strings.HasPrefix(name, "X-Remove-"+kind+"-"):
+			stored.Del("X-" + kind + "-" + strings.TrimPrefix(name, "X-Remove-"+kind+"-"))
+		case strings.HasPrefix(name, "X-"+kind+"-"), name == "X-Cdn-Enabled":
+			stored[name] = values
+		}
+	}
+}
+
+func TestSwiftClient_Container(t *testing.T) {
+	server := newTestSwiftServer("SLOS123-2:SL123", "secret")
+	defer server.Close()
+
+	client := newSwiftClient(server.authURL(), "SLOS123-2:SL123", "secret")
+
+	headers := http.Header{}
+	swiftMetadataHeaders(swiftContainerMetaPrefix, map[string]interface{}{"Owner": "web", "env": "test"}, headers)
+	headers.Set("X-Container-Read", ".r:*")
+
+	if err := client.createContainer("artifacts", headers); err != nil {
+		t.Fatalf("Error creating container: %s", err)
+	}
+
+	respHeaders, err := client.headContainer("artifacts")
+	if err != nil {
+		t.Fatalf("Error retrieving container: %s", err)
+	}
+
+	metadata := readSwiftMetadata(swiftContainerMetaPrefix, respHeaders, map[string]interface{}{"Owner": ""})
+	if metadata["Owner"] != "web" || metadata["env"] != "test" || len(metadata) != 2 {
+		t.Fatalf("Unexpected container metadata: %v", metadata)
+	}
+
+	if acl := respHeaders.Get("X-Container-Read"); acl != ".r:*" {
+		t.Fatalf("Unexpected container read ACL: %s", acl)
+	}
+
+	update := http.Header{}
+	update.Set("X-Remove-Container-Meta-env", "x")
+	update.Set("X-Remove-Container-Read", "x")
+	update.Set("X-Cdn-Enabled", "True")
+	if err := client.updateContainer("artifacts", update); err != nil {
+		t.Fatalf("Error updating container: %s", err)
+	}
+
+	respHeaders, err = client.headContainer("artifacts")
+	if err != nil {
+		t.Fatalf("Error retrieving container: %s", err)
+	}
+
+	metadata = readSwiftMetadata(swiftContainerMetaPrefix, respHeaders, nil)
+	if len(metadata) != 1 || metadata["owner"] != "web" {
+		t.Fatalf("Unexpected container metadata after update: %v", metadata)
+	}
+
+	if acl := respHeaders.Get("X-Container-Read"); acl != "" {
+		t.Fatalf("Container read ACL was not removed: %s", acl)
+	}
+
+	if cdn := respHeaders.Get("X-Cdn-Enabled"); cdn != "True" {
+		t.Fatalf("CDN was not enabled: %s", cdn)
+	}
+
+	if err := client.deleteContainer("artifacts"); err != nil {
+		t.Fatalf("Error deleting container: %s", err)
+	}
+
+	if _, err := client.headContainer("artifacts"); !isSwiftNotFound(err) {
+		t.Fatalf("Expected a not found error after delete, got: %v", err)
+	}
+}
+
+func TestSwiftClient_Object(t *testing.T) {
+	server := newTestSwiftServer("user", "key")
+	defer server.Close()
+
+	client := newSwiftClient(server.authURL(), "user", "key")
+
+	if err := client.createContainer("artifacts", http.Header{}); err != nil {
+		t.Fatalf("Error creating container: %s", err)
+	}
+
+	content := "hello world"
+	headers := http.Header{}
+	headers.Set("Content-Type", "text/plain")
+	swiftMetadataHeaders(swiftObjectMetaPrefix, map[string]interface{}{"build": "42"}, headers)
+
+	etag, err := client.putObject("artifacts", "builds/app 1.txt", headers, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Error uploading object: %s", err)
+	}
+
+	hash := md5.Sum([]byte(content))
+	if etag != hex.EncodeToString(hash[:]) {
+		t.Fatalf("Unexpected ETag %s", etag)
+	}
+
+	respHeaders, err := client.headObject("artifacts", "builds/app 1.txt")
+	if err != nil {
+		t.Fatalf("Error retrieving object: %s", err)
+	}
+
+	if contentType := respHeaders.Get("Content-Type"); contentType != "text/plain" {
+		t.Fatalf("Unexpected content type %s", contentType)
+	}
+
+	if length := headerInt(respHeaders, "Content-Length"); length != len(content) {
+		t.Fatalf("Unexpected content length %d", length)
+	}
+
+	if metadata := readSwiftMetadata(swiftObjectMetaPrefix, respHeaders, nil); metadata["build"] != "42" {
+		t.Fatalf("Unexpected object metadata: %v", metadata)
+	}
+
+	if err := client.deleteContainer("artifacts"); err == nil {
+		t.Fatalf("Expected an error when deleting a container which is not empty")
+	} else if apiErr, ok := err.(swiftError); !ok || apiErr.StatusCode != http.StatusConflict {
+		t.Fatalf("Expected a conflict error, got: %s", err)
+	}
+
+	if err := client.deleteObject("artifacts", "builds/app 1.txt"); err != nil {
+		t.Fatalf("Error deleting object: %s", err)
+	}
+
+	if _, err := client.headObject("artifacts", "builds/app 1.txt"); !isSwiftNotFound(err) {
+		t.Fatalf("Expected a not found error after delete, got: %v", err)
+	}
+}
+
+func TestSwiftClient_ListObjects(t *testing.T) {
+	server := newTestSwiftServer("user", "key")
+	defer server.Close()
+
+	client := newSwiftClient(server.authURL(), "user", "key")
+
+	if err := client.createContainer("artifacts", http.Header{}); err != nil {
+		t.Fatalf("Error creating container: %s", err)
+	}
+
+	expected := []string{"a", "b", "c", "d", "e"}
+	for _, name := range expected {
+		if _, err := client.putObject("artifacts", name, http.Header{}, strings.NewReader(name)); err != nil {
+			t.Fatalf("Error uploading object: %s", err)
+		}
+	}
+
+	names, err := client.listObjects("artifacts")
+	if err != nil {
+		t.Fatalf("Error listing objects: %s", err)
+	}
+
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Unexpected objects %v", names)
+	}
+}
+
+func TestSwiftClient_Reauthenticate(t *testing.T) {
+	server := newTestSwiftServer("user", "key")
+	defer server.Close()
+
+	client := newSwiftClient(server.authURL(), "user", "key")
+
+	if err := client.createContainer("artifacts", http.Header{}); err != nil {
+		t.Fatalf("Error creating container: %s", err)
+	}
+
+	server.expireToken()
+
+	if _, err := client.putObject("artifacts", "retried", http.Header{}, strings.NewReader("content")); err != nil {
+		t.Fatalf("Error uploading object after the token expired: %s", err)
+	}
+
+	respHeaders, err := client.headObject("artifacts", "retried")
+	if err != nil {
+		t.Fatalf("Error retrieving object: %s", err)
+	}
+
+	if length := headerInt(respHeaders, "Content-Length"); length != len("content") {
+		t.Fatalf("The object body was not sent again on retry, length %d", length)
+	}
+}
+
+func TestSwiftClient_BadCredentials(t *testing.T) {
+	server := newTestSwiftServer("user", "key")
+	defer server.Close()
+
+	client := newSwiftClient(server.authURL(), "user", "wrong")
+
+	if err := client.createContainer("artifacts", http.Header{}); err == nil {
+		t.Fatalf("Expected an authentication error")
+	}
+}
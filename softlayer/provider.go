@@ -46,32 +46,58 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"softlayer_ssh_key":        dataSourceSoftLayerSSHKey(),
-			"softlayer_image_template": dataSourceSoftLayerImageTemplate(),
-			"softlayer_vlan":           dataSourceSoftLayerVlan(),
+			"softlayer_ssh_key":         dataSourceSoftLayerSSHKey(),
+			"softlayer_image_template":  dataSourceSoftLayerImageTemplate(),
+			"softlayer_vlan":            dataSourceSoftLayerVlan(),
+			"softlayer_subnet":          dataSourceSoftLayerSubnet(),
+			"softlayer_ip_address":      dataSourceSoftLayerIpAddress(),
+			"softlayer_network_gateway": dataSourceSoftLayerNetworkGateway(),
+			"softlayer_dns_zone_file":   dataSourceSoftLayerDnsZoneFile(),
+			"softlayer_dns_domain":      dataSourceSoftLayerDnsDomain(),
+			"softlayer_dns_records":     dataSourceSoftLayerDnsRecords(),
+			"softlayer_lb_local":        dataSourceSoftLayerLbLocal(),
+			"softlayer_lb_vpx":          dataSourceSoftLayerLbVpx(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"softlayer_virtual_guest":          resourceSoftLayerVirtualGuest(),
-			"softlayer_bare_metal":             resourceSoftLayerBareMetal(),
-			"softlayer_ssh_key":                resourceSoftLayerSSHKey(),
-			"softlayer_dns_domain_record":      resourceSoftLayerDnsDomainRecord(),
-			"softlayer_dns_domain":             resourceSoftLayerDnsDomain(),
-			"softlayer_lb_vpx":                 resourceSoftLayerLbVpx(),
-			"softlayer_lb_vpx_vip":             resourceSoftLayerLbVpxVip(),
-			"softlayer_lb_vpx_service":         resourceSoftLayerLbVpxService(),
-			"softlayer_lb_local":               resourceSoftLayerLbLocal(),
-			"softlayer_lb_local_service_group": resourceSoftLayerLbLocalServiceGroup(),
-			"softlayer_lb_local_service":       resourceSoftLayerLbLocalService(),
-			"softlayer_security_certificate":   resourceSoftLayerSecurityCertificate(),
-			"softlayer_user":                   resourceSoftLayerUser(),
-			"softlayer_objectstorage_account":  resourceSoftLayerObjectStorageAccount(),
-			"softlayer_provisioning_hook":      resourceSoftLayerProvisioningHook(),
-			"softlayer_scale_policy":           resourceSoftLayerScalePolicy(),
-			"softlayer_scale_group":            resourceSoftLayerScaleGroup(),
-			"softlayer_basic_monitor":          resourceSoftLayerBasicMonitor(),
-			"softlayer_vlan":                   resourceSoftLayerVlan(),
-			"softlayer_global_ip":              resourceSoftLayerGlobalIp(),
+			"softlayer_virtual_guest":                    resourceSoftLayerVirtualGuest(),
+			"softlayer_bare_metal":                       resourceSoftLayerBareMetal(),
+			"softlayer_ssh_key":                          resourceSoftLayerSSHKey(),
+			"softlayer_dns_domain_record":                resourceSoftLayerDnsDomainRecord(),
+			"softlayer_dns_domain":                       resourceSoftLayerDnsDomain(),
+			"softlayer_lb_vpx":                           resourceSoftLayerLbVpx(),
+			"softlayer_lb_vpx_vip":                       resourceSoftLayerLbVpxVip(),
+			"softlayer_lb_vpx_service":                   resourceSoftLayerLbVpxService(),
+			"softlayer_lb_local":                         resourceSoftLayerLbLocal(),
+			"softlayer_lb_local_service_group":           resourceSoftLayerLbLocalServiceGroup(),
+			"softlayer_lb_local_service":                 resourceSoftLayerLbLocalService(),
+			"softlayer_security_certificate":             resourceSoftLayerSecurityCertificate(),
+			"softlayer_user":                             resourceSoftLayerUser(),
+			"softlayer_objectstorage_account":            resourceSoftLayerObjectStorageAccount(),
+			"softlayer_objectstorage_container":          resourceSoftLayerObjectStorageContainer(),
+			"softlayer_objectstorage_object":             resourceSoftLayerObjectStorageObject(),
+			"softlayer_cos_account":                      resourceSoftLayerCosAccount(),
+			"softlayer_cos_bucket":                       resourceSoftLayerCosBucket(),
+			"softlayer_provisioning_hook":                resourceSoftLayerProvisioningHook(),
+			"softlayer_scale_policy":                     resourceSoftLayerScalePolicy(),
+			"softlayer_scale_group":                      resourceSoftLayerScaleGroup(),
+			"softlayer_basic_monitor":                    resourceSoftLayerBasicMonitor(),
+			"softlayer_vlan":                             resourceSoftLayerVlan(),
+			"softlayer_global_ip":                        resourceSoftLayerGlobalIp(),
+			"softlayer_subnet":                           resourceSoftLayerSubnet(),
+			"softlayer_vlan_firewall":                    resourceSoftLayerVlanFirewall(),
+			"softlayer_firewall_policy":                  resourceSoftLayerFirewallPolicy(),
+			"softlayer_server_firewall":                  resourceSoftLayerServerFirewall(),
+			"softlayer_network_gateway_vlan_association": resourceSoftLayerNetworkGatewayVlanAssociation(),
+			"softlayer_ipsec_vpn":                        resourceSoftLayerIpsecVpn(),
+			"softlayer_reverse_dns_record":               resourceSoftLayerReverseDnsRecord(),
+			"softlayer_dns_secondary":                    resourceSoftLayerDnsSecondary(),
+			"softlayer_domain_registration":              resourceSoftLayerDomainRegistration(),
+			"softlayer_global_lb":                        resourceSoftLayerGlobalLb(),
+			"softlayer_global_lb_host":                   resourceSoftLayerGlobalLbHost(),
+			"softlayer_file_storage":                     resourceSoftLayerFileStorage(),
+			"softlayer_storage_snapshot_schedule":        resourceSoftLayerStorageSnapshotSchedule(),
+			"softlayer_storage_replica":                  resourceSoftLayerStorageReplica(),
 		},
 
 		ConfigureFunc: providerConfigure,
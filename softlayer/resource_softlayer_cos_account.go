@@ -0,0 +1,389 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	CosPackageType  = "ADDITIONAL_SERVICES_CLOUD_OBJECT_STORAGE"
+	CosCategoryCode = "cloud_object_storage"
+
+	cosAccountMask = "id,username,uuid,credentials[id,username,password,type[keyName]]"
+)
+
+func resourceSoftLayerCosAccount() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerCosAccountCreate,
+		Read:     resourceSoftLayerCosAccountRead,
+		Update:   resourceSoftLayerCosAccountUpdate,
+		Delete:   resourceSoftLayerCosAccountDelete,
+		Exists:   resourceSoftLayerCosAccountExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uuid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"credential_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if v.(int) < 0 {
+						errs = append(errs, fmt.Errorf("credential_count should not be negative"))
+					}
+					return
+				},
+			},
+			"credentials": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"access_key_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"secret_access_key": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerCosAccountCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	pkg, err := product.GetPackageByType(sess, CosPackageType)
+	if err != nil {
+		return fmt.Errorf("Error creating cloud object storage account: %s", err)
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id,
+		"id,keyName,prices[id,locationGroupId,categories[categoryCode]]")
+	if err != nil {
+		return fmt.Errorf("Error creating cloud object storage account: %s", err)
+	}
+
+	var price *datatypes.Product_Item_Price
+	for _, item := range productItems {
+		for _, p := range item.Prices {
+			if p.LocationGroupId == nil && storagePriceHasCategory(p, CosCategoryCode) {
+				price = &datatypes.Product_Item_Price{Id: p.Id}
+				break
+			}
+		}
+		if price != nil {
+			break
+		}
+	}
+
+	if price == nil {
+		return fmt.Errorf("Error creating cloud object storage account: no %s price found in package %d",
+			CosCategoryCode, *pkg.Id)
+	}
+
+	log.Println("[INFO] Creating cloud object storage account")
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(&datatypes.Container_Product_Order{
+		PackageId: pkg.Id,
+		Prices:    []datatypes.Product_Item_Price{*price},
+		Quantity:  sl.Int(1),
+	}, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of cloud object storage account: %s", err)
+	}
+
+	cosAccount, err := findCosAccountByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of cloud object storage account: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *cosAccount.Id))
+	log.Printf("[INFO] Cloud Object Storage Account ID: %s", d.Id())
+
+	err = updateCosAccountCredentials(sess, *cosAccount.Id, d.Get("credential_count").(int))
+	if err != nil {
+		return fmt.Errorf("Error creating cloud object storage account credentials: %s", err)
+	}
+
+	return resourceSoftLayerCosAccountRead(d, meta)
+}
+
+func resourceSoftLayerCosAccountRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageHubCleversafeAccountService(sess)
+
+	cosAccountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid cloud object storage account ID, must be an integer: %s", err)
+	}
+
+	cosAccount, err := service.Id(cosAccountId).Mask(cosAccountMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving cloud object storage account: %s", err)
+	}
+
+	d.Set("id", *cosAccount.Id)
+	d.Set("name", sl.Get(cosAccount.Username, ""))
+	d.Set("uuid", sl.Get(cosAccount.Uuid, ""))
+	d.Set("credential_count", len(cosAccount.Credentials))
+
+	credentials := make([]map[string]interface{}, 0, len(cosAccount.Credentials))
+	for _, credential := range cosAccount.Credentials {
+		credentials = append(credentials, map[string]interface{}{
+			"id":                *credential.Id,
+			"access_key_id":     sl.Get(credential.Username, ""),
+			"secret_access_key": sl.Get(credential.Password, ""),
+		})
+	}
+	d.Set("credentials", credentials)
+
+	endpoints, err := service.Id(cosAccountId).GetEndpoints()
+	if err != nil {
+		return fmt.Errorf("Error retrieving cloud object storage account endpoints: %s", err)
+	}
+
+	endpointList := make([]map[string]interface{}, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		endpointList = append(endpointList, map[string]interface{}{
+			"location": sl.Get(endpoint.Location, ""),
+			"region":   sl.Get(endpoint.Region, ""),
+			"type":     sl.Get(endpoint.Type, ""),
+			"url":      sl.Get(endpoint.Url, ""),
+		})
+	}
+	d.Set("endpoints", endpointList)
+
+	return nil
+}
+
+func resourceSoftLayerCosAccountUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	cosAccountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid cloud object storage account ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("credential_count") {
+		err = updateCosAccountCredentials(sess, cosAccountId, d.Get("credential_count").(int))
+		if err != nil {
+			return fmt.Errorf("Error updating cloud object storage account credentials: %s", err)
+		}
+	}
+
+	return resourceSoftLayerCosAccountRead(d, meta)
+}
+
+func resourceSoftLayerCosAccountDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageHubCleversafeAccountService(sess)
+
+	cosAccountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid cloud object storage account ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(cosAccountId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting cloud object storage account: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelItem(
+		sl.Bool(true), sl.Bool(true), sl.String("No longer required"),
+		sl.String("Please cancel this cloud object storage account"),
+	)
+	if err != nil {
+		return fmt.Errorf("Error canceling the cloud object storage account (%d): %s", cosAccountId, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerCosAccountExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageHubCleversafeAccountService(sess)
+
+	cosAccountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid cloud object storage account ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(cosAccountId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving cloud object storage account: %s", err)
+	}
+
+	return result.Id != nil && *result.Id == cosAccountId, nil
+}
+
+// updateCosAccountCredentials creates or deletes HMAC credentials until the account has the given number
+// of credentials. The most recent credentials are deleted first.
+func updateCosAccountCredentials(sess *session.Session, cosAccountId int, count int) error {
+	service := services.GetNetworkStorageHubCleversafeAccountService(sess).Id(cosAccountId)
+
+	credentials, err := service.Mask("id").GetCredentials()
+	if err != nil {
+		return err
+	}
+
+	if count > len(credentials) {
+		limit, err := service.GetCredentialLimit()
+		if err != nil {
+			return err
+		}
+
+		if count > limit {
+			return fmt.Errorf("credential_count %d exceeds the credential limit of the account (%d)", count, limit)
+		}
+	}
+
+	for i := len(credentials); i < count; i++ {
+		log.Printf("[INFO] Creating credential on cloud object storage account %d", cosAccountId)
+		_, err = service.CredentialCreate()
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := len(credentials) - 1; i >= count; i-- {
+		log.Printf("[INFO] Deleting credential %d of cloud object storage account %d", *credentials[i].Id, cosAccountId)
+		_, err = service.CredentialDelete(&datatypes.Network_Storage_Credential{Id: credentials[i].Id})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getCosAccountCredentials returns the first HMAC credential of a cloud object storage account
+func getCosAccountCredentials(sess *session.Session, cosAccountId int) (accessKey string, secretKey string, err error) {
+	credentials, err := services.GetNetworkStorageHubCleversafeAccountService(sess).Id(cosAccountId).
+		Mask("id,username,password").
+		GetCredentials()
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, credential := range credentials {
+		if credential.Username != nil && credential.Password != nil {
+			return *credential.Username, *credential.Password, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("Cloud object storage account %d has no credentials. Set credential_count to create one",
+		cosAccountId)
+}
+
+func findCosAccountByOrderId(sess *session.Session, orderId int) (datatypes.Network_Storage, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			accounts, err := services.GetAccountService(sess).
+				Filter(filter.Build(
+					filter.Path("hubNetworkStorage.billingItem.orderItem.order.id").Eq(strconv.Itoa(orderId)),
+				)).
+				Mask("id,vendorName").
+				GetHubNetworkStorage()
+			if err != nil {
+				return datatypes.Network_Storage{}, "", err
+			}
+
+			cosAccounts := []datatypes.Network_Storage{}
+			for _, account := range accounts {
+				if strings.Contains(strings.ToLower(sl.Get(account.VendorName, "").(string)), "cleversafe") {
+					cosAccounts = append(cosAccounts, account)
+				}
+			}
+
+			if len(cosAccounts) == 1 {
+				return cosAccounts[0], "complete", nil
+			} else if len(cosAccounts) == 0 {
+				return nil, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one cloud object storage account for order %d", orderId)
+			}
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Storage{}, err
+	}
+
+	if result, ok := pendingResult.(datatypes.Network_Storage); ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Storage{},
+		fmt.Errorf("Cannot find cloud object storage account with order id '%d'", orderId)
+}
@@ -0,0 +1,79 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerCosAccount_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCosAccountConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerCosAccountExists("softlayer_cos_account.cos"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_account.cos", "credential_count", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_account.cos", "credentials.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCosAccountConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_account.cos", "credential_count", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_account.cos", "credentials.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerCosAccountExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		cosAccountId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageHubCleversafeAccountService(testAccProvider.Meta().(*session.Session))
+		foundAccount, err := service.Id(cosAccountId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundAccount.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerCosAccountConfig_basic = `
+resource "softlayer_cos_account" "cos" {
+}`
+
+const testAccCheckSoftLayerCosAccountConfig_updated = `
+resource "softlayer_cos_account" "cos" {
+    credential_count = 2
+}`
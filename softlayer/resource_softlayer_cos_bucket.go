@@ -0,0 +1,336 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+var cosBucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func resourceSoftLayerCosBucket() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerCosBucketCreate,
+		Read:     resourceSoftLayerCosBucketRead,
+		Update:   resourceSoftLayerCosBucketUpdate,
+		Delete:   resourceSoftLayerCosBucketDelete,
+		Exists:   resourceSoftLayerCosBucketExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cos_account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"endpoint": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if !cosBucketNameRegexp.MatchString(v.(string)) {
+						errs = append(errs, fmt.Errorf(
+							"%q must be 3 to 63 lowercase letters, digits, dots or hyphens, "+
+								"starting and ending with a letter or a digit", k))
+					}
+					return
+				},
+			},
+			"location_constraint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"versioning": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"lifecycle_rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"expiration_days": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"noncurrent_version_expiration_days": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"transition_days": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"transition_storage_class": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "GLACIER",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerCosBucketCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getCosBucketClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error creating cloud object storage bucket: %s", err)
+	}
+
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Creating cloud object storage bucket %s", name)
+
+	err = client.createBucket(name, d.Get("location_constraint").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating cloud object storage bucket: %s", err)
+	}
+
+	endpoint, err := url.Parse(d.Get("endpoint").(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing cloud object storage endpoint: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s/%s", d.Get("cos_account_id").(int), endpoint.Host, name))
+
+	if d.Get("versioning").(bool) {
+		err = client.putBucketVersioning(name, "Enabled")
+		if err != nil {
+			return fmt.Errorf("Error enabling versioning on cloud object storage bucket: %s", err)
+		}
+	}
+
+	if rules := expandCosLifecycleRules(d.Get("lifecycle_rule").([]interface{})); len(rules) > 0 {
+		err = client.putBucketLifecycle(name, rules)
+		if err != nil {
+			return fmt.Errorf("Error setting lifecycle rules on cloud object storage bucket: %s", err)
+		}
+	}
+
+	return resourceSoftLayerCosBucketRead(d, meta)
+}
+
+func resourceSoftLayerCosBucketRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getCosBucketClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error retrieving cloud object storage bucket: %s", err)
+	}
+
+	name := d.Get("name").(string)
+
+	location, err := client.getBucketLocation(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving cloud object storage bucket location: %s", err)
+	}
+	d.Set("location_constraint", location)
+
+	status, err := client.getBucketVersioning(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving cloud object storage bucket versioning: %s", err)
+	}
+	d.Set("versioning", status == "Enabled")
+
+	rules, err := client.getBucketLifecycle(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving cloud object storage bucket lifecycle: %s", err)
+	}
+	d.Set("lifecycle_rule", flattenCosLifecycleRules(rules))
+
+	return nil
+}
+
+func resourceSoftLayerCosBucketUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getCosBucketClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error updating cloud object storage bucket: %s", err)
+	}
+
+	name := d.Get("name").(string)
+
+	// Versioning cannot be disabled once enabled, only suspended
+	if d.HasChange("versioning") {
+		status := "Suspended"
+		if d.Get("versioning").(bool) {
+			status = "Enabled"
+		}
+
+		err = client.putBucketVersioning(name, status)
+		if err != nil {
+			return fmt.Errorf("Error updating cloud object storage bucket versioning: %s", err)
+		}
+	}
+
+	if d.HasChange("lifecycle_rule") {
+		err = client.putBucketLifecycle(name, expandCosLifecycleRules(d.Get("lifecycle_rule").([]interface{})))
+		if err != nil {
+			return fmt.Errorf("Error updating cloud object storage bucket lifecycle: %s", err)
+		}
+	}
+
+	return resourceSoftLayerCosBucketRead(d, meta)
+}
+
+func resourceSoftLayerCosBucketDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getCosBucketClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error deleting cloud object storage bucket: %s", err)
+	}
+
+	name := d.Get("name").(string)
+
+	err = client.deleteBucket(name)
+	if err != nil && !isS3NotFound(err) {
+		if apiErr, ok := err.(s3Error); ok && apiErr.Code == "BucketNotEmpty" {
+			return fmt.Errorf("Error deleting cloud object storage bucket %s: the bucket is not empty", name)
+		}
+		return fmt.Errorf("Error deleting cloud object storage bucket: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerCosBucketExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, err := getCosBucketClient(d, meta)
+	if err != nil {
+		return false, fmt.Errorf("Error retrieving cloud object storage bucket: %s", err)
+	}
+
+	err = client.headBucket(d.Get("name").(string))
+	if err != nil {
+		if isS3NotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving cloud object storage bucket: %s", err)
+	}
+
+	return true, nil
+}
+
+// getCosBucketClient returns an S3 client authenticated with the first HMAC credential of the account.
+// The account, endpoint and name are taken from the ID when they are not set, as after an import.
+func getCosBucketClient(d *schema.ResourceData, meta interface{}) (*cosClient, error) {
+	sess := meta.(*session.Session)
+
+	if d.Get("name").(string) == "" && d.Id() != "" {
+		parts := strings.SplitN(d.Id(), "/", 3)
+		if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf(
+				"Not a valid cloud object storage bucket ID, must be <account id>/<endpoint host>/<name>: %s", d.Id())
+		}
+
+		cosAccountId, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("Not a valid cloud object storage account ID, must be an integer: %s", err)
+		}
+
+		d.Set("cos_account_id", cosAccountId)
+		d.Set("endpoint", "https://"+parts[1])
+		d.Set("name", parts[2])
+	}
+
+	accessKey, secretKey, err := getCosAccountCredentials(sess, d.Get("cos_account_id").(int))
+	if err != nil {
+		return nil, err
+	}
+
+	return newCosClient(d.Get("endpoint").(string), accessKey, secretKey), nil
+}
+
+func expandCosLifecycleRules(configured []interface{}) []s3LifecycleRule {
+	rules := make([]s3LifecycleRule, 0, len(configured))
+
+	for _, r := range configured {
+		ruleMap := r.(map[string]interface{})
+		rule := s3LifecycleRule{
+			ID:     ruleMap["id"].(string),
+			Prefix: ruleMap["prefix"].(string),
+			Status: "Disabled",
+		}
+
+		if ruleMap["enabled"].(bool) {
+			rule.Status = "Enabled"
+		}
+
+		if days := ruleMap["expiration_days"].(int); days > 0 {
+			rule.Expiration = &s3LifecycleExpiration{Days: days}
+		}
+
+		if days := ruleMap["noncurrent_version_expiration_days"].(int); days > 0 {
+			rule.NoncurrentVersionExpiration = &s3LifecycleNoncurrentExpiration{NoncurrentDays: days}
+		}
+
+		if days := ruleMap["transition_days"].(int); days > 0 {
+			rule.Transition = &s3LifecycleTransition{
+				Days:         days,
+				StorageClass: ruleMap["transition_storage_class"].(string),
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func flattenCosLifecycleRules(rules []s3LifecycleRule) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(rules))
+
+	for _, rule := range rules {
+		ruleMap := map[string]interface{}{
+			"id":                       rule.ID,
+			"prefix":                   rule.Prefix,
+			"enabled":                  rule.Status == "Enabled",
+			"transition_storage_class": "GLACIER",
+		}
+
+		if rule.Expiration != nil {
+			ruleMap["expiration_days"] = rule.Expiration.Days
+		}
+
+		if rule.NoncurrentVersionExpiration != nil {
+			ruleMap["noncurrent_version_expiration_days"] = rule.NoncurrentVersionExpiration.NoncurrentDays
+		}
+
+		if rule.Transition != nil {
+			ruleMap["transition_days"] = rule.Transition.Days
+			ruleMap["transition_storage_class"] = rule.Transition.StorageClass
+		}
+
+		flattened = append(flattened, ruleMap)
+	}
+
+	return flattened
+}
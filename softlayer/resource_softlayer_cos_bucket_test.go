@@ -0,0 +1,147 @@
+package softlayer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerCosBucket_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCosBucketConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerCosBucketExists("softlayer_cos_bucket.bucket"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.bucket", "location_constraint", "us-standard"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.bucket", "versioning", "false"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCosBucketConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.bucket", "versioning", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.bucket", "lifecycle_rule.#", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.bucket", "lifecycle_rule.0.id", "archive-logs"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.bucket", "lifecycle_rule.0.transition_days", "30"),
+				),
+			},
+		},
+	})
+}
+
+func TestCosLifecycleRules(t *testing.T) {
+	configured := []interface{}{
+		map[string]interface{}{
+			"id":                                 "archive",
+			"prefix":                             "logs/",
+			"enabled":                            true,
+			"expiration_days":                    365,
+			"noncurrent_version_expiration_days": 0,
+			"transition_days":                    30,
+			"transition_storage_class":           "GLACIER",
+		},
+		map[string]interface{}{
+			"id":                                 "cleanup",
+			"prefix":                             "",
+			"enabled":                            false,
+			"expiration_days":                    0,
+			"noncurrent_version_expiration_days": 7,
+			"transition_days":                    0,
+			"transition_storage_class":           "GLACIER",
+		},
+	}
+
+	rules := expandCosLifecycleRules(configured)
+
+	if rules[0].Status != "Enabled" || rules[0].Transition == nil || rules[0].Expiration == nil ||
+		rules[0].NoncurrentVersionExpiration != nil {
+		t.Fatalf("Unexpected expanded rule %+v", rules[0])
+	}
+
+	if rules[1].Status != "Disabled" || rules[1].Transition != nil || rules[1].Expiration != nil ||
+		rules[1].NoncurrentVersionExpiration == nil {
+		t.Fatalf("Unexpected expanded rule %+v", rules[1])
+	}
+
+	flattened := flattenCosLifecycleRules(rules)
+	for i, rule := range flattened {
+		for key, value := range rule {
+			if !reflect.DeepEqual(configured[i].(map[string]interface{})[key], value) {
+				t.Fatalf("Unexpected %s of flattened rule %d: %v", key, i, value)
+			}
+		}
+	}
+}
+
+func testAccCheckSoftLayerCosBucketExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		parts := strings.SplitN(rs.Primary.ID, "/", 3)
+		if len(parts) != 3 {
+			return fmt.Errorf("Not a valid cloud object storage bucket ID: %s", rs.Primary.ID)
+		}
+
+		cosAccountId, _ := strconv.Atoi(parts[0])
+		accessKey, secretKey, err := getCosAccountCredentials(testAccProvider.Meta().(*session.Session), cosAccountId)
+		if err != nil {
+			return err
+		}
+
+		return newCosClient("https://"+parts[1], accessKey, secretKey).headBucket(parts[2])
+	}
+}
+
+const testAccCheckSoftLayerCosBucketConfig_basic = `
+resource "softlayer_cos_account" "cos" {
+}
+
+resource "softlayer_cos_bucket" "bucket" {
+    cos_account_id = "${softlayer_cos_account.cos.id}"
+    endpoint = "https://s3-api.us-geo.objectstorage.softlayer.net"
+    name = "terraform-acc-test-bucket"
+    location_constraint = "us-standard"
+}`
+
+const testAccCheckSoftLayerCosBucketConfig_updated = `
+resource "softlayer_cos_account" "cos" {
+}
+
+resource "softlayer_cos_bucket" "bucket" {
+    cos_account_id = "${softlayer_cos_account.cos.id}"
+    endpoint = "https://s3-api.us-geo.objectstorage.softlayer.net"
+    name = "terraform-acc-test-bucket"
+    location_constraint = "us-standard"
+    versioning = true
+
+    lifecycle_rule {
+        id = "archive-logs"
+        prefix = "logs/"
+        transition_days = 30
+        expiration_days = 365
+    }
+}`
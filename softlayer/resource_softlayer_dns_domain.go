@@ -1,11 +1,15 @@
 package softlayer
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/services"
@@ -13,6 +17,8 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 )
 
+const DnsDomainMask = "id,name,serial,updateDate,resourceRecords"
+
 func resourceSoftLayerDnsDomain() *schema.Resource {
 	return &schema.Resource{
 		Exists:   resourceSoftLayerDnsDomainExists,
@@ -47,6 +53,55 @@ func resourceSoftLayerDnsDomain() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			"records": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDnsDomainRecordHostValue,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDnsDomainRecordsType,
+						},
+						"data": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDnsDomainRecordDataValue,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  86400,
+						},
+						"mx_priority": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validateDnsDomainRecordPriority,
+						},
+					},
+				},
+				Set:           resourceSoftLayerDnsDomainRecordsHash,
+				ConflictsWith: []string{"zone_file"},
+			},
+
+			"manage_ns_records": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"zone_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"records"},
+			},
 		},
 	}
 }
@@ -70,6 +125,24 @@ func resourceSoftLayerDnsDomainCreate(d *schema.ResourceData, meta interface{})
 		},
 	}
 
+	// Records of an authoritative record set are created along with the domain
+	for _, r := range d.Get("records").(*schema.Set).List() {
+		opts.ResourceRecords = append(opts.ResourceRecords, expandDnsDomainRecord(r.(map[string]interface{})))
+	}
+
+	// Records of a zone file are created along with the domain, except SRV records which need their own service
+	zoneRecords, err := getDnsDomainZoneFileRecords(d, d.Get("zone_file").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating Dns Domain: %s", err)
+	}
+	otherRecords, srvRecords := splitDnsDomainSrvRecords(zoneRecords)
+	opts.ResourceRecords = append(opts.ResourceRecords, otherRecords...)
+
+	err = validateDnsDomainRecordSet(append(opts.ResourceRecords, srvRecords...))
+	if err != nil {
+		return fmt.Errorf("Error creating Dns Domain: %s", err)
+	}
+
 	// create Dns_Domain object
 	response, err := service.CreateObject(&opts)
 	if err != nil {
@@ -81,6 +154,13 @@ func resourceSoftLayerDnsDomainCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(strconv.Itoa(id))
 	log.Printf("[INFO] Created Dns Domain: %d", id)
 
+	if len(srvRecords) > 0 {
+		err = applyDnsDomainRecordChanges(sess, id, srvRecords, nil, nil)
+		if err != nil {
+			return fmt.Errorf("Error creating the SRV records of Dns Domain %d: %s", id, err)
+		}
+	}
+
 	// read remote state
 	return resourceSoftLayerDnsDomainRead(d, meta)
 }
@@ -92,9 +172,7 @@ func resourceSoftLayerDnsDomainRead(d *schema.ResourceData, meta interface{}) er
 	dnsId, _ := strconv.Atoi(d.Id())
 
 	// retrieve remote object state
-	dns_domain, err := service.Id(dnsId).Mask(
-		"id,name,updateDate,resourceRecords",
-	).GetObject()
+	dns_domain, err := service.Id(dnsId).Mask(DnsDomainMask).GetObject()
 	if err != nil {
 		return fmt.Errorf("Error retrieving Dns Domain %d: %s", dnsId, err)
 	}
@@ -105,12 +183,25 @@ func resourceSoftLayerDnsDomainRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("update_date", sl.Get(dns_domain.UpdateDate, nil))
 
 	// find a record with host @; that will have the current target.
+	// With several apex A records, the one of the current target stays the target
+	target := ""
 	for _, record := range dns_domain.ResourceRecords {
 		if *record.Type == "a" && *record.Host == "@" {
-			d.Set("target", *record.Data)
-			break
+			if target == "" || *record.Data == d.Get("target").(string) {
+				target = *record.Data
+			}
 		}
 	}
+	if target != "" {
+		d.Set("target", target)
+	}
+
+	// Records are only read back when the record set is managed, so that unmanaged records show as drift
+	if d.Get("records").(*schema.Set).Len() > 0 {
+		managed := filterDnsDomainManagedRecords(dns_domain.ResourceRecords, d.Get("target").(string),
+			d.Get("manage_ns_records").(bool))
+		d.Set("records", flattenDnsDomainRecords(managed))
+	}
 
 	return nil
 }
@@ -120,38 +211,51 @@ func resourceSoftLayerDnsDomainUpdate(d *schema.ResourceData, meta interface{})
 	sess := meta.(*session.Session)
 	domainId, _ := strconv.Atoi(d.Id())
 
-	if !d.HasChange("target") { // target is the only editable field
+	if d.HasChange("records") || d.HasChange("manage_ns_records") {
+		err := updateDnsDomainRecords(d, sess, domainId)
+		if err != nil {
+			return fmt.Errorf("Error updating the records of Dns Domain %d: %s", domainId, err)
+		}
+	}
+
+	if d.HasChange("zone_file") {
+		err := updateDnsDomainZoneFileRecords(d, sess, domainId)
+		if err != nil {
+			return fmt.Errorf("Error updating the zone file records of Dns Domain %d: %s", domainId, err)
+		}
+	}
+
+	if !d.HasChange("target") {
 		return nil
 	}
 
-	newTarget := d.Get("target").(string)
+	oldTarget, newTarget := d.GetChange("target")
 
 	// retrieve domain state
 	domainService := services.GetDnsDomainService(sess)
-	domain, err := domainService.Id(domainId).Mask(
-		"id,name,updateDate,resourceRecords",
-	).GetObject()
+	domain, err := domainService.Id(domainId).Mask(DnsDomainMask).GetObject()
 	if err != nil {
 		return fmt.Errorf("Error retrieving DNS resource %d: %s", domainId, err)
 	}
 
-	// find a record with host @; that will have the current target.
-	var record datatypes.Dns_Domain_ResourceRecord
-	for _, record = range domain.ResourceRecords {
-		if *record.Type == "a" && *record.Host == "@" {
+	// find the record with host @ and the current target, as Read does with several apex A records
+	var record *datatypes.Dns_Domain_ResourceRecord
+	for i, r := range domain.ResourceRecords {
+		if *r.Type == "a" && *r.Host == "@" && sl.Get(r.Data, "").(string) == oldTarget.(string) {
+			record = &domain.ResourceRecords[i]
 			break
 		}
 	}
 
-	if record.Id == nil {
-		return fmt.Errorf("Could not find DNS target record for domain %s (%d)",
-			sl.Get(domain.Name), sl.Get(domain.Id))
+	if record == nil || record.Id == nil {
+		return fmt.Errorf("Could not find DNS target record %s for domain %s (%d)",
+			oldTarget.(string), sl.Get(domain.Name), sl.Get(domain.Id))
 	}
 
-	record.Data = sl.String(newTarget)
+	record.Data = sl.String(newTarget.(string))
 
 	_, err = services.GetDnsDomainResourceRecordService(sess).
-		Id(*record.Id).EditObject(&record)
+		Id(*record.Id).EditObject(record)
 
 	if err != nil {
 		return fmt.Errorf("Error editing DNS target record for domain %s (%d): %s",
@@ -196,3 +300,330 @@ func resourceSoftLayerDnsDomainExists(d *schema.ResourceData, meta interface{})
 	result, err := service.Id(dnsId).GetObject()
 	return err == nil && result.Id != nil && *result.Id == dnsId, nil
 }
+
+func validateDnsDomainRecordsType(v interface{}, k string) (ws []string, errs []error) {
+	value := v.(string)
+	if value == "ns" {
+		return
+	}
+	for _, rtype := range allowedDomainRecordTypes {
+		// SRV records need fields which are only supported by softlayer_dns_domain_record
+		if value == rtype && value != "srv" {
+			return
+		}
+	}
+	errs = append(errs, fmt.Errorf("%q must be a lower case record type other than srv: %s", k, value))
+	return
+}
+
+func resourceSoftLayerDnsDomainRecordsHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["host"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["type"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["data"].(string)))
+	buf.WriteString(fmt.Sprintf("%d-", m["ttl"].(int)))
+	buf.WriteString(fmt.Sprintf("%d-", m["mx_priority"].(int)))
+	return hashcode.String(buf.String())
+}
+
+// dnsDomainRecordKey identifies a record within its domain. Records with the same key are edited in place.
+func dnsDomainRecordKey(record datatypes.Dns_Domain_ResourceRecord) string {
+	parts := []string{
+		sl.Get(record.Host, "").(string),
+		sl.Get(record.Type, "").(string),
+		sl.Get(record.Data, "").(string),
+	}
+	if sl.Get(record.Type, "") == "srv" {
+		parts = append(parts, sl.Get(record.Service, "").(string), sl.Get(record.Protocol, "").(string))
+	}
+	return strings.Join(parts, "|")
+}
+
+func expandDnsDomainRecord(m map[string]interface{}) datatypes.Dns_Domain_ResourceRecord {
+	record := datatypes.Dns_Domain_ResourceRecord{
+		Host: sl.String(m["host"].(string)),
+		Type: sl.String(m["type"].(string)),
+		Data: sl.String(m["data"].(string)),
+		Ttl:  sl.Int(m["ttl"].(int)),
+	}
+	if *record.Type == "mx" {
+		record.MxPriority = sl.Int(m["mx_priority"].(int))
+	}
+	return record
+}
+
+func flattenDnsDomainRecords(records []datatypes.Dns_Domain_ResourceRecord) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		m := map[string]interface{}{
+			"host":        sl.Get(record.Host, ""),
+			"type":        sl.Get(record.Type, ""),
+			"data":        sl.Get(record.Data, ""),
+			"ttl":         sl.Get(record.Ttl, 0),
+			"mx_priority": 0,
+		}
+		if sl.Get(record.Type, "") == "mx" {
+			m["mx_priority"] = sl.Get(record.MxPriority, 0)
+		}
+		flattened = append(flattened, m)
+	}
+	return flattened
+}
+
+// filterDnsDomainManagedRecords returns the records reconciled by the records block of a domain. The SOA
+// record, SRV records and the apex A record of the target are never part of it, NS records only on request.
+func filterDnsDomainManagedRecords(records []datatypes.Dns_Domain_ResourceRecord, target string,
+	manageNs bool) []datatypes.Dns_Domain_ResourceRecord {
+
+	managed := make([]datatypes.Dns_Domain_ResourceRecord, 0, len(records))
+	targetSkipped := false
+	for _, record := range records {
+		recordType := sl.Get(record.Type, "").(string)
+		switch {
+		case recordType == "soa" || recordType == "srv":
+			continue
+		case recordType == "ns" && !manageNs:
+			continue
+		case recordType == "a" && sl.Get(record.Host, "") == "@" && sl.Get(record.Data, "") == target && !targetSkipped:
+			targetSkipped = true
+			continue
+		}
+		managed = append(managed, record)
+	}
+	return managed
+}
+
+// diffDnsDomainRecords compares the current records of a domain with the desired ones. Records are matched
+// on host, type and data, matching records with other TTL, priorities, weight or port are edited in place.
+func diffDnsDomainRecords(current, desired []datatypes.Dns_Domain_ResourceRecord) (
+	create, edit, remove []datatypes.Dns_Domain_ResourceRecord) {
+
+	existing := map[string][]datatypes.Dns_Domain_ResourceRecord{}
+	for _, record := range current {
+		key := dnsDomainRecordKey(record)
+		existing[key] = append(existing[key], record)
+	}
+
+	for _, record := range desired {
+		key := dnsDomainRecordKey(record)
+
+		matches := existing[key]
+		if len(matches) == 0 {
+			create = append(create, record)
+			continue
+		}
+
+		match := matches[0]
+		existing[key] = matches[1:]
+
+		if sl.Get(match.Ttl, 0) != sl.Get(record.Ttl, 0) ||
+			sl.Get(match.MxPriority, 0) != sl.Get(record.MxPriority, 0) ||
+			sl.Get(match.Priority, 0) != sl.Get(record.Priority, 0) ||
+			sl.Get(match.Weight, 0) != sl.Get(record.Weight, 0) ||
+			sl.Get(match.Port, 0) != sl.Get(record.Port, 0) {
+			match.Ttl = record.Ttl
+			match.MxPriority = record.MxPriority
+			match.Priority = record.Priority
+			match.Weight = record.Weight
+			match.Port = record.Port
+			edit = append(edit, match)
+		}
+	}
+
+	for _, key := range sortedDnsDomainRecordKeys(existing) {
+		remove = append(remove, existing[key]...)
+	}
+
+	return
+}
+
+func sortedDnsDomainRecordKeys(records map[string][]datatypes.Dns_Domain_ResourceRecord) []string {
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// updateDnsDomainRecords reconciles the records of a domain with the records block
+func updateDnsDomainRecords(d *schema.ResourceData, sess *session.Session, domainId int) error {
+	configured := d.Get("records").(*schema.Set).List()
+
+	// The record set is no longer managed once the records block is removed
+	if len(configured) == 0 {
+		return nil
+	}
+
+	desired := make([]datatypes.Dns_Domain_ResourceRecord, 0, len(configured))
+	for _, r := range configured {
+		desired = append(desired, expandDnsDomainRecord(r.(map[string]interface{})))
+	}
+
+	if err := validateDnsDomainRecordSet(desired); err != nil {
+		return err
+	}
+
+	current, err := getDnsDomainRecords(sess, domainId)
+	if err != nil {
+		return err
+	}
+
+	// The records are updated before the target, so the apex record still holds the previous target
+	target, _ := d.GetChange("target")
+	create, edit, remove := diffDnsDomainRecords(
+		filterDnsDomainManagedRecords(current, target.(string), d.Get("manage_ns_records").(bool)), desired)
+
+	return applyDnsDomainRecordChanges(sess, domainId, create, edit, remove)
+}
+
+// getDnsDomainZoneFileRecords parses a zone file of the domain. The A record of the domain itself with the
+// target address is left out, as it is managed by target.
+func getDnsDomainZoneFileRecords(d *schema.ResourceData, zoneFile string) ([]datatypes.Dns_Domain_ResourceRecord, error) {
+	if zoneFile == "" {
+		return nil, nil
+	}
+
+	parsed, err := parseZoneFile(zoneFile, d.Get("name").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing zone file: %s", err)
+	}
+
+	records := make([]datatypes.Dns_Domain_ResourceRecord, 0, len(parsed))
+	for _, record := range parsed {
+		if *record.Type == "a" && *record.Host == "@" && *record.Data == d.Get("target").(string) {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// updateDnsDomainZoneFileRecords applies the changes between the previous and the new zone file. Records
+// which are not in either zone file are left untouched.
+func updateDnsDomainZoneFileRecords(d *schema.ResourceData, sess *session.Session, domainId int) error {
+	o, n := d.GetChange("zone_file")
+
+	oldRecords, err := getDnsDomainZoneFileRecords(d, o.(string))
+	if err != nil {
+		// The previous zone file was applied, so it can only fail to parse after a change of the parser
+		log.Printf("[WARN] Unable to parse the previous zone file of Dns Domain %d: %s", domainId, err)
+	}
+
+	newRecords, err := getDnsDomainZoneFileRecords(d, n.(string))
+	if err != nil {
+		return err
+	}
+
+	if err := validateDnsDomainRecordSet(newRecords); err != nil {
+		return err
+	}
+
+	zoneKeys := map[string]bool{}
+	for _, record := range append(oldRecords, newRecords...) {
+		zoneKeys[dnsDomainRecordKey(record)] = true
+	}
+
+	current, err := getDnsDomainRecords(sess, domainId)
+	if err != nil {
+		return err
+	}
+
+	zoneRecords := make([]datatypes.Dns_Domain_ResourceRecord, 0, len(current))
+	for _, record := range current {
+		if zoneKeys[dnsDomainRecordKey(record)] {
+			zoneRecords = append(zoneRecords, record)
+		}
+	}
+
+	create, edit, remove := diffDnsDomainRecords(zoneRecords, newRecords)
+
+	return applyDnsDomainRecordChanges(sess, domainId, create, edit, remove)
+}
+
+func getDnsDomainRecords(sess *session.Session, domainId int) ([]datatypes.Dns_Domain_ResourceRecord, error) {
+	return services.GetDnsDomainService(sess).Id(domainId).
+		Mask("id,domainId,host,type,data,ttl,mxPriority,service,protocol,priority,weight,port").
+		GetResourceRecords()
+}
+
+// applyDnsDomainRecordChanges creates, edits and deletes records of a domain with at most one API call
+// per change and record kind, as SRV records are handled by their own service.
+func applyDnsDomainRecordChanges(sess *session.Session, domainId int,
+	create, edit, remove []datatypes.Dns_Domain_ResourceRecord) error {
+
+	service := services.GetDnsDomainResourceRecordService(sess)
+	srvService := services.GetDnsDomainResourceRecordSrvTypeService(sess)
+
+	if len(remove) > 0 {
+		log.Printf("[INFO] Deleting %d records of Dns Domain %d", len(remove), domainId)
+		_, err := service.DeleteObjects(remove)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(edit) > 0 {
+		records, srvRecords := splitDnsDomainSrvRecords(edit)
+
+		log.Printf("[INFO] Editing %d records of Dns Domain %d", len(edit), domainId)
+		if len(records) > 0 {
+			_, err := service.EditObjects(records)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(srvRecords) > 0 {
+			srvEdits := make([]datatypes.Dns_Domain_ResourceRecord_SrvType, 0, len(srvRecords))
+			for _, record := range srvRecords {
+				srvEdits = append(srvEdits, datatypes.Dns_Domain_ResourceRecord_SrvType{
+					Dns_Domain_ResourceRecord: record,
+				})
+			}
+			_, err := srvService.EditObjects(srvEdits)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(create) > 0 {
+		for i := range create {
+			create[i].DomainId = sl.Int(domainId)
+		}
+		records, srvRecords := splitDnsDomainSrvRecords(create)
+
+		log.Printf("[INFO] Creating %d records of Dns Domain %d", len(create), domainId)
+		if len(records) > 0 {
+			_, err := service.CreateObjects(records)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(srvRecords) > 0 {
+			_, err := srvService.CreateObjects(srvRecords)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func splitDnsDomainSrvRecords(records []datatypes.Dns_Domain_ResourceRecord) (
+	others, srvRecords []datatypes.Dns_Domain_ResourceRecord) {
+
+	for _, record := range records {
+		if sl.Get(record.Type, "") == "srv" {
+			srvRecords = append(srvRecords, record)
+		} else {
+			others = append(others, record)
+		}
+	}
+	return
+}
@@ -3,7 +3,6 @@ package softlayer
 import (
 	"fmt"
 	"log"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -17,16 +16,6 @@ import (
 var allowedDomainRecordTypes = []string{
 	"a", "aaaa", "cname", "mx", "ptr", "spf", "srv", "txt",
 }
-var ipv6Regexp *regexp.Regexp
-var upcaseRegexp *regexp.Regexp
-
-func init() {
-	ipv6Regexp, _ = regexp.Compile(
-		"[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:" +
-			"[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}",
-	)
-	upcaseRegexp, _ = regexp.Compile("[A-Z]")
-}
 
 func resourceSoftLayerDnsDomainRecord() *schema.Resource {
 	return &schema.Resource{
@@ -43,21 +32,9 @@ func resourceSoftLayerDnsDomainRecord() *schema.Resource {
 			},
 
 			"data": {
-				Type:     schema.TypeString,
-				Required: true,
-				ValidateFunc: func(val interface{}, field string) (warnings []string, errors []error) {
-					value := val.(string)
-					if ipv6Regexp.MatchString(value) && upcaseRegexp.MatchString(value) {
-						errors = append(
-							errors,
-							fmt.Errorf(
-								"IPv6 addresses in the data property cannot have upper case letters: %s",
-								value,
-							),
-						)
-					}
-					return
-				},
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDnsDomainRecordDataValue,
 			},
 
 			"domain_id": {
@@ -72,14 +49,16 @@ func resourceSoftLayerDnsDomainRecord() *schema.Resource {
 			},
 
 			"host": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDnsDomainRecordHostValue,
 			},
 
 			"mx_priority": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDnsDomainRecordPriority,
 			},
 
 			"refresh": {
@@ -147,19 +126,22 @@ func resourceSoftLayerDnsDomainRecord() *schema.Resource {
 			},
 
 			"port": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateDnsDomainRecordPort,
 			},
 
 			"priority": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDnsDomainRecordPriority,
 			},
 
 			"weight": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateDnsDomainRecordPriority,
 			},
 		},
 	}
@@ -187,7 +169,10 @@ func resourceSoftLayerDnsDomainRecordCreate(d *schema.ResourceData, meta interfa
 		opts.Minimum = sl.Int(minimum.(int))
 	}
 
-	if mxPriority, ok := d.GetOk("mx_priority"); ok {
+	// A mx_priority of 0 is valid, so it can't be read with GetOk
+	if *opts.Type == "mx" && isComputedSettingConfigured(d, "mx_priority") {
+		opts.MxPriority = sl.Int(d.Get("mx_priority").(int))
+	} else if mxPriority, ok := d.GetOk("mx_priority"); ok {
 		opts.MxPriority = sl.Int(mxPriority.(int))
 	}
 
@@ -228,9 +213,13 @@ func resourceSoftLayerDnsDomainRecordCreate(d *schema.ResourceData, meta interfa
 		}
 	}
 
+	err := validateDnsDomainRecordInDomain(sess, expandDnsDomainRecordResource(d))
+	if err != nil {
+		return fmt.Errorf("Error creating DNS Resource %s Record: %s", *opts.Type, err)
+	}
+
 	log.Printf("[INFO] Creating DNS Resource %s Record for '%d' dns domain", *opts.Type, d.Get("id"))
 
-	var err error
 	var id int
 	if *opts.Type == "srv" {
 		var record datatypes.Dns_Domain_ResourceRecord_SrvType
@@ -313,6 +302,11 @@ func resourceSoftLayerDnsDomainRecordUpdate(d *schema.ResourceData, meta interfa
 
 	recordType := d.Get("type").(string)
 
+	err = validateDnsDomainRecordInDomain(sess, expandDnsDomainRecordResource(d))
+	if err != nil {
+		return fmt.Errorf("Error editing DNS Resource %s Record %d: %s", recordType, recordId, err)
+	}
+
 	if data, ok := d.GetOk("data"); ok && d.HasChange("data") {
 		record.Data = sl.String(data.(string))
 	}
@@ -337,8 +331,8 @@ func resourceSoftLayerDnsDomainRecordUpdate(d *schema.ResourceData, meta interfa
 		record.Minimum = sl.Int(minimum_ttl.(int))
 	}
 
-	if mx_priority, ok := d.GetOk("mx_priority"); ok && d.HasChange("mx_priority") {
-		record.MxPriority = sl.Int(mx_priority.(int))
+	if d.HasChange("mx_priority") {
+		record.MxPriority = sl.Int(d.Get("mx_priority").(int))
 	}
 
 	if refresh, ok := d.GetOk("refresh"); ok && d.HasChange("refresh") {
@@ -427,3 +421,38 @@ func resourceSoftLayerDnsDomainRecordExists(d *schema.ResourceData, meta interfa
 
 	return err == nil && record.Id != nil && *record.Id == id, nil
 }
+
+// expandDnsDomainRecordResource returns the record configured by a softlayer_dns_domain_record resource
+func expandDnsDomainRecordResource(d *schema.ResourceData) datatypes.Dns_Domain_ResourceRecord {
+	record := datatypes.Dns_Domain_ResourceRecord{
+		Data:     sl.String(d.Get("data").(string)),
+		DomainId: sl.Int(d.Get("domain_id").(int)),
+		Host:     sl.String(d.Get("host").(string)),
+		Type:     sl.String(d.Get("type").(string)),
+	}
+
+	if id, err := strconv.Atoi(d.Id()); err == nil {
+		record.Id = sl.Int(id)
+	}
+
+	// mx records without a configured mx_priority are left without one, so that they are rejected
+	if *record.Type == "mx" && isComputedSettingConfigured(d, "mx_priority") {
+		record.MxPriority = sl.Int(d.Get("mx_priority").(int))
+	} else if mxPriority, ok := d.GetOk("mx_priority"); ok {
+		record.MxPriority = sl.Int(mxPriority.(int))
+	}
+
+	if serviceName, ok := d.GetOk("service"); ok {
+		record.Service = sl.String(serviceName.(string))
+	}
+
+	if protocol, ok := d.GetOk("protocol"); ok {
+		record.Protocol = sl.String(protocol.(string))
+	}
+
+	if port, ok := d.GetOk("port"); ok {
+		record.Port = sl.Int(port.(int))
+	}
+
+	return record
+}
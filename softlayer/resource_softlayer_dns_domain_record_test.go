@@ -167,6 +167,7 @@ resource "softlayer_dns_domain_record" "recordMX" {
     domain_id = "${softlayer_dns_domain.test_dns_domain_record_types.id}"
     host = "hosta-mx.com"
     responsible_person = "user@softlayer.com"
+    mx_priority = 10
     ttl = 900
     type = "mx"
 }
@@ -68,6 +68,93 @@ func TestAccSoftLayerDnsDomain_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerDnsDomain_Records(t *testing.T) {
+	var dns_domain datatypes.Dns_Domain
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerDnsDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(configRecords, domainName3, target1, "172.16.0.102", 900),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDnsDomainExists("softlayer_dns_domain.acceptance_test_dns_domain-records", &dns_domain),
+					testAccCheckSoftLayerDnsDomainAttributes(&dns_domain),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_domain.acceptance_test_dns_domain-records", "records.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(configRecords, domainName3, target1, "172.16.0.103", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDnsDomainExists("softlayer_dns_domain.acceptance_test_dns_domain-records", &dns_domain),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_domain.acceptance_test_dns_domain-records", "records.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestDnsDomainRecordsDiff(t *testing.T) {
+	current := []datatypes.Dns_Domain_ResourceRecord{
+		{Id: sl.Int(1), Host: sl.String("www"), Type: sl.String("a"), Data: sl.String("10.0.0.1"), Ttl: sl.Int(900)},
+		{Id: sl.Int(2), Host: sl.String("mail"), Type: sl.String("mx"), Data: sl.String("mx.example.com."),
+			Ttl: sl.Int(900), MxPriority: sl.Int(10)},
+		{Id: sl.Int(3), Host: sl.String("old"), Type: sl.String("cname"), Data: sl.String("www"), Ttl: sl.Int(900)},
+	}
+
+	desired := []datatypes.Dns_Domain_ResourceRecord{
+		expandDnsDomainRecord(map[string]interface{}{"host": "www", "type": "a", "data": "10.0.0.1", "ttl": 900, "mx_priority": 0}),
+		expandDnsDomainRecord(map[string]interface{}{"host": "mail", "type": "mx", "data": "mx.example.com.", "ttl": 900, "mx_priority": 20}),
+		expandDnsDomainRecord(map[string]interface{}{"host": "api", "type": "a", "data": "10.0.0.2", "ttl": 300, "mx_priority": 0}),
+	}
+
+	create, edit, remove := diffDnsDomainRecords(current, desired)
+
+	if len(create) != 1 || *create[0].Host != "api" {
+		t.Errorf("Expected the api record to be created, got %d records", len(create))
+	}
+
+	if len(edit) != 1 || *edit[0].Id != 2 || *edit[0].MxPriority != 20 {
+		t.Errorf("Expected the mail record to be edited, got %d records", len(edit))
+	}
+
+	if len(remove) != 1 || *remove[0].Id != 3 {
+		t.Errorf("Expected the old record to be removed, got %d records", len(remove))
+	}
+}
+
+func TestDnsDomainManagedRecords(t *testing.T) {
+	records := []datatypes.Dns_Domain_ResourceRecord{
+		{Host: sl.String("@"), Type: sl.String("soa"), Data: sl.String("ns1.softlayer.com.")},
+		{Host: sl.String("@"), Type: sl.String("ns"), Data: sl.String("ns1.softlayer.com.")},
+		{Host: sl.String("@"), Type: sl.String("a"), Data: sl.String("10.0.0.1")},
+		{Host: sl.String("_sip._tcp"), Type: sl.String("srv"), Data: sl.String("sip")},
+		{Host: sl.String("www"), Type: sl.String("a"), Data: sl.String("10.0.0.1")},
+	}
+
+	if managed := filterDnsDomainManagedRecords(records, "10.0.0.1", false); len(managed) != 1 {
+		t.Errorf("Expected 1 managed record, got %d", len(managed))
+	}
+
+	if managed := filterDnsDomainManagedRecords(records, "10.0.0.1", true); len(managed) != 2 {
+		t.Errorf("Expected 2 managed records with NS records, got %d", len(managed))
+	}
+
+	// Only the apex A record of the target is skipped, other apex A records are managed
+	apexRecords := []datatypes.Dns_Domain_ResourceRecord{
+		{Host: sl.String("@"), Type: sl.String("a"), Data: sl.String("10.0.0.2")},
+		{Host: sl.String("@"), Type: sl.String("a"), Data: sl.String("10.0.0.1")},
+	}
+
+	managed := filterDnsDomainManagedRecords(apexRecords, "10.0.0.1", false)
+	if len(managed) != 1 || *managed[0].Data != "10.0.0.2" {
+		t.Errorf("Expected the apex record 10.0.0.2 to be managed, got %d records", len(managed))
+	}
+}
+
 func testAccCheckSoftLayerDnsDomainDestroy(s *terraform.State) error {
 	service := services.GetDnsDomainService(testAccProvider.Meta().(*session.Session))
 
@@ -204,8 +291,30 @@ resource "softlayer_dns_domain" "acceptance_test_dns_domain-1" {
 }
 `
 
+var configRecords = `
+resource "softlayer_dns_domain" "acceptance_test_dns_domain-records" {
+	name = "%s"
+	target = "%s"
+
+	records {
+		host = "www"
+		type = "a"
+		data = "%s"
+		ttl = %d
+	}
+
+	records {
+		host = "@"
+		type = "mx"
+		data = "mail.example.com."
+		mx_priority = 10
+	}
+}
+`
+
 var domainName1 = "zxczcxzxc.com"
 var domainName2 = "vbnvnvbnv.com"
+var domainName3 = "qwerqwerqwer.com"
 var target1 = "172.16.0.100"
 var target2 = "172.16.0.101"
 var firstDnsId = 0
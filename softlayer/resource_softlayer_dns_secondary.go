@@ -0,0 +1,218 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const DnsSecondaryMask = "id,zoneName,masterIpAddress,transferFrequency,lastUpdate,statusId,statusText,status[name]," +
+	"errorMessages[createDate,message]"
+
+func resourceSoftLayerDnsSecondary() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerDnsSecondaryCreate,
+		Read:     resourceSoftLayerDnsSecondaryRead,
+		Update:   resourceSoftLayerDnsSecondaryUpdate,
+		Delete:   resourceSoftLayerDnsSecondaryDelete,
+		Exists:   resourceSoftLayerDnsSecondaryExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"zone_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"master_ip_address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"transfer_frequency": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"transfer_on_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status_text": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_update": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerDnsSecondaryCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	opts := datatypes.Dns_Secondary{
+		ZoneName:          sl.String(d.Get("zone_name").(string)),
+		MasterIpAddress:   sl.String(d.Get("master_ip_address").(string)),
+		TransferFrequency: sl.Int(d.Get("transfer_frequency").(int)),
+	}
+
+	log.Printf("[INFO] Creating secondary zone %s", *opts.ZoneName)
+
+	secondary, err := service.CreateObject(&opts)
+	if err != nil {
+		return fmt.Errorf("Error creating secondary zone: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *secondary.Id))
+
+	return resourceSoftLayerDnsSecondaryRead(d, meta)
+}
+
+func resourceSoftLayerDnsSecondaryRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid secondary zone ID, must be an integer: %s", err)
+	}
+
+	secondary, err := service.Id(secondaryId).Mask(DnsSecondaryMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving secondary zone: %s", err)
+	}
+
+	d.Set("id", *secondary.Id)
+	d.Set("zone_name", sl.Get(secondary.ZoneName, ""))
+	d.Set("master_ip_address", sl.Get(secondary.MasterIpAddress, ""))
+	d.Set("transfer_frequency", sl.Get(secondary.TransferFrequency, 0))
+	d.Set("status_id", sl.Get(secondary.StatusId, 0))
+	d.Set("status_text", sl.Get(secondary.StatusText, ""))
+
+	if secondary.Status != nil {
+		d.Set("status", sl.Get(secondary.Status.Name, ""))
+	}
+
+	if secondary.LastUpdate != nil {
+		d.Set("last_update", secondary.LastUpdate.String())
+	}
+
+	return dnsSecondaryTransferError(secondary)
+}
+
+func resourceSoftLayerDnsSecondaryUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid secondary zone ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("master_ip_address") || d.HasChange("transfer_frequency") {
+		opts := datatypes.Dns_Secondary{
+			MasterIpAddress:   sl.String(d.Get("master_ip_address").(string)),
+			TransferFrequency: sl.Int(d.Get("transfer_frequency").(int)),
+		}
+
+		_, err = service.Id(secondaryId).EditObject(&opts)
+		if err != nil {
+			return fmt.Errorf("Error updating secondary zone: %s", err)
+		}
+
+		if d.Get("transfer_on_change").(bool) {
+			log.Printf("[INFO] Transferring secondary zone %d from its master", secondaryId)
+
+			_, err = service.Id(secondaryId).TransferNow()
+			if err != nil {
+				return fmt.Errorf("Error transferring secondary zone: %s", err)
+			}
+		}
+	}
+
+	return resourceSoftLayerDnsSecondaryRead(d, meta)
+}
+
+func resourceSoftLayerDnsSecondaryDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid secondary zone ID, must be an integer: %s", err)
+	}
+
+	_, err = service.Id(secondaryId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting secondary zone: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerDnsSecondaryExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid secondary zone ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(secondaryId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving secondary zone: %s", err)
+	}
+	return result.Id != nil && *result.Id == secondaryId, nil
+}
+
+// dnsSecondaryTransferError returns an error with the messages of the last transfer when it failed
+func dnsSecondaryTransferError(secondary datatypes.Dns_Secondary) error {
+	status := ""
+	if secondary.Status != nil {
+		status = sl.Get(secondary.Status.Name, "").(string)
+	}
+
+	if !strings.Contains(strings.ToLower(status), "error") {
+		return nil
+	}
+
+	messages := make([]string, 0, len(secondary.ErrorMessages))
+	for _, message := range secondary.ErrorMessages {
+		if message.Message != nil {
+			messages = append(messages, *message.Message)
+		}
+	}
+
+	if len(messages) == 0 {
+		messages = append(messages, sl.Get(secondary.StatusText, status).(string))
+	}
+
+	return fmt.Errorf("Zone transfer of secondary zone %s failed: %s",
+		sl.Get(secondary.ZoneName, ""), strings.Join(messages, "; "))
+}
@@ -0,0 +1,101 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerDnsSecondary_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDnsSecondaryConfig, "172.16.0.1", 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDnsSecondaryExists("softlayer_dns_secondary.secondary"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.secondary", "zone_name", "tfacc-secondary.com"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.secondary", "master_ip_address", "172.16.0.1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.secondary", "transfer_frequency", "10"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDnsSecondaryConfig, "172.16.0.2", 15),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDnsSecondaryExists("softlayer_dns_secondary.secondary"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.secondary", "master_ip_address", "172.16.0.2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.secondary", "transfer_frequency", "15"),
+				),
+			},
+		},
+	})
+}
+
+func TestDnsSecondaryTransferError(t *testing.T) {
+	active := datatypes.Dns_Secondary{
+		ZoneName: sl.String("example.com"),
+		Status:   &datatypes.Dns_Status{Name: sl.String("Active")},
+	}
+	if err := dnsSecondaryTransferError(active); err != nil {
+		t.Errorf("Expected no error for an active zone, got %s", err)
+	}
+
+	failed := datatypes.Dns_Secondary{
+		ZoneName:      sl.String("example.com"),
+		Status:        &datatypes.Dns_Status{Name: sl.String("Transfer Error")},
+		ErrorMessages: []datatypes.Dns_Message{{Message: sl.String("Connection refused")}},
+	}
+	if err := dnsSecondaryTransferError(failed); err == nil {
+		t.Error("Expected an error for a failed transfer")
+	}
+}
+
+func testAccCheckSoftLayerDnsSecondaryExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		secondaryId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetDnsSecondaryService(testAccProvider.Meta().(*session.Session))
+		foundSecondary, err := service.Id(secondaryId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundSecondary.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerDnsSecondaryConfig = `
+resource "softlayer_dns_secondary" "secondary" {
+    zone_name = "tfacc-secondary.com"
+    master_ip_address = "%s"
+    transfer_frequency = %d
+}`
@@ -0,0 +1,528 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	DomainRegistrationCategoryCode = "domain_registration"
+	DomainRegistrationContactType  = "registrant"
+)
+
+func resourceSoftLayerDomainRegistration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerDomainRegistrationCreate,
+		Read:   resourceSoftLayerDomainRegistrationRead,
+		Update: resourceSoftLayerDomainRegistrationUpdate,
+		Delete: resourceSoftLayerDomainRegistrationDelete,
+		Exists: resourceSoftLayerDomainRegistrationExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSoftLayerDomainRegistrationImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"nameservers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"auto_renew": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"registrant_contact": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"first_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"last_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"organization_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"address1": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"address2": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"address3": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"city": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"postal_code": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"phone": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"fax": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"expire_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"registrant_verification_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Domains can't be registered by the provider, the resource takes over the management of a registered domain
+func resourceSoftLayerDomainRegistrationCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	registration, err := findDomainRegistrationByName(sess, d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d", *registration.Id))
+
+	log.Printf("[INFO] Managing domain registration %s (%d)", d.Get("name").(string), *registration.Id)
+
+	// The domain already exists, so the configuration is compared with its current settings rather than
+	// with the empty state
+	previous, err := getDomainRegistrationSettings(sess, *registration.Id, d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Error retrieving domain registration: %s", err)
+	}
+
+	err = applyDomainRegistrationSettings(d, sess, *registration.Id, previous)
+	if err != nil {
+		return err
+	}
+
+	return resourceSoftLayerDomainRegistrationRead(d, meta)
+}
+
+func resourceSoftLayerDomainRegistrationRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsDomainRegistrationService(sess)
+
+	registrationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid domain registration ID, must be an integer: %s", err)
+	}
+
+	registration, err := service.Id(registrationId).
+		Mask("id,name,lockedFlag,expireDate,domainRegistrationStatus[keyName],registrantVerificationStatus[keyName]").
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving domain registration: %s", err)
+	}
+
+	d.Set("id", *registration.Id)
+	d.Set("name", sl.Get(registration.Name, ""))
+	d.Set("locked", sl.Get(registration.LockedFlag, 0).(int) == 1)
+
+	if registration.ExpireDate != nil {
+		d.Set("expire_date", registration.ExpireDate.String())
+	}
+
+	if registration.DomainRegistrationStatus != nil {
+		d.Set("status", sl.Get(registration.DomainRegistrationStatus.KeyName, ""))
+	}
+
+	if registration.RegistrantVerificationStatus != nil {
+		d.Set("registrant_verification_status", sl.Get(registration.RegistrantVerificationStatus.KeyName, ""))
+	}
+
+	nameservers, err := service.Id(registrationId).GetDomainNameservers()
+	if err != nil {
+		return fmt.Errorf("Error retrieving the nameservers of domain registration: %s", err)
+	}
+	d.Set("nameservers", flattenDomainRegistrationNameservers(nameservers))
+
+	information, err := service.Id(registrationId).GetDomainInformation()
+	if err != nil {
+		return fmt.Errorf("Error retrieving the contacts of domain registration: %s", err)
+	}
+	for _, contact := range information.Contacts {
+		if sl.Get(contact.Type, "") == DomainRegistrationContactType {
+			d.Set("registrant_contact", flattenDomainRegistrationContact(contact))
+			break
+		}
+	}
+
+	billingItem, err := getDomainRegistrationBillingItem(sess, *registration.Name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the billing item of domain registration: %s", err)
+	}
+	if billingItem != nil {
+		d.Set("auto_renew", billingItem.CancellationDate == nil)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerDomainRegistrationUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	registrationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid domain registration ID, must be an integer: %s", err)
+	}
+
+	oldNameservers, _ := d.GetChange("nameservers")
+	oldLocked, _ := d.GetChange("locked")
+	oldAutoRenew, _ := d.GetChange("auto_renew")
+
+	err = applyDomainRegistrationSettings(d, sess, registrationId, domainRegistrationSettings{
+		nameservers: oldNameservers.(*schema.Set),
+		locked:      oldLocked.(bool),
+		autoRenew:   oldAutoRenew.(bool),
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceSoftLayerDomainRegistrationRead(d, meta)
+}
+
+// domainRegistrationSettings holds the settings of a domain registration which the configuration is compared with
+type domainRegistrationSettings struct {
+	nameservers *schema.Set
+	locked      bool
+	autoRenew   bool
+}
+
+// getDomainRegistrationSettings returns the current settings of a domain registration
+func getDomainRegistrationSettings(sess *session.Session, registrationId int, name string) (domainRegistrationSettings, error) {
+	service := services.GetDnsDomainRegistrationService(sess)
+
+	registration, err := service.Id(registrationId).Mask("id,lockedFlag").GetObject()
+	if err != nil {
+		return domainRegistrationSettings{}, err
+	}
+
+	nameservers, err := service.Id(registrationId).GetDomainNameservers()
+	if err != nil {
+		return domainRegistrationSettings{}, err
+	}
+
+	billingItem, err := getDomainRegistrationBillingItem(sess, name)
+	if err != nil {
+		return domainRegistrationSettings{}, err
+	}
+
+	return domainRegistrationSettings{
+		nameservers: schema.NewSet(schema.HashString, flattenDomainRegistrationNameservers(nameservers)),
+		locked:      sl.Get(registration.LockedFlag, 0).(int) == 1,
+		autoRenew:   billingItem != nil && billingItem.CancellationDate == nil,
+	}, nil
+}
+
+// isComputedSettingConfigured returns whether an Optional and Computed setting has a value. During
+// Create GetOk can't tell an explicit false or zero from a missing value, but missing values are computed
+// and computed values are left out of the state.
+func isComputedSettingConfigured(d *schema.ResourceData, key string) bool {
+	_, ok := d.State().Attributes[key]
+	return ok
+}
+
+// applyDomainRegistrationSettings changes the settings of a domain registration which differ between the
+// previous settings and the configuration. Settings which aren't configured are kept.
+func applyDomainRegistrationSettings(d *schema.ResourceData, sess *session.Session, registrationId int,
+	previous domainRegistrationSettings) error {
+
+	service := services.GetDnsDomainRegistrationService(sess)
+
+	if isComputedSettingConfigured(d, "nameservers.#") {
+		nameservers := d.Get("nameservers").(*schema.Set)
+		add := nameservers.Difference(previous.nameservers).List()
+		remove := previous.nameservers.Difference(nameservers).List()
+
+		// Nameservers are added first, as a domain can't be left without nameservers
+		if len(add) > 0 {
+			_, err := service.Id(registrationId).AddNameserversToDomain(expandStringList(add))
+			if err != nil {
+				return fmt.Errorf("Error adding nameservers to domain registration: %s", err)
+			}
+		}
+
+		if len(remove) > 0 {
+			_, err := service.Id(registrationId).RemoveNameserversFromDomain(expandStringList(remove))
+			if err != nil {
+				return fmt.Errorf("Error removing nameservers from domain registration: %s", err)
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("registrant_contact"); ok && d.HasChange("registrant_contact") {
+		contact := expandDomainRegistrationContact(v.([]interface{})[0].(map[string]interface{}))
+		_, err := service.Id(registrationId).ModifyContact(&contact)
+		if err != nil {
+			return fmt.Errorf("Error modifying the registrant contact of domain registration: %s", err)
+		}
+	}
+
+	if locked := d.Get("locked").(bool); isComputedSettingConfigured(d, "locked") && locked != previous.locked {
+		var err error
+		if locked {
+			_, err = service.Id(registrationId).LockDomain()
+		} else {
+			_, err = service.Id(registrationId).UnlockDomain()
+		}
+		if err != nil {
+			return fmt.Errorf("Error changing the lock of domain registration: %s", err)
+		}
+	}
+
+	if autoRenew := d.Get("auto_renew").(bool); isComputedSettingConfigured(d, "auto_renew") &&
+		autoRenew != previous.autoRenew {
+
+		err := setDomainRegistrationAutoRenew(sess, d.Get("name").(string), autoRenew)
+		if err != nil {
+			return fmt.Errorf("Error changing the renewal of domain registration: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// The registration of the domain is kept, it is only removed from the state
+func resourceSoftLayerDomainRegistrationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Removing domain registration %s from the state, the domain stays registered", d.Get("name").(string))
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerDomainRegistrationExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetDnsDomainRegistrationService(sess)
+
+	registrationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid domain registration ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(registrationId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving domain registration: %s", err)
+	}
+	return result.Id != nil && *result.Id == registrationId, nil
+}
+
+// resourceSoftLayerDomainRegistrationImport imports a domain registration by name or ID
+func resourceSoftLayerDomainRegistrationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	registration, err := findDomainRegistrationByName(meta.(*session.Session), d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(fmt.Sprintf("%d", *registration.Id))
+	d.Set("name", sl.Get(registration.Name, ""))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func findDomainRegistrationByName(sess *session.Session, name string) (datatypes.Dns_Domain_Registration, error) {
+	registrations, err := services.GetAccountService(sess).
+		Filter(filter.Path("domainRegistrations.name").Eq(name).Build()).
+		Mask("id,name").
+		GetDomainRegistrations()
+	if err != nil {
+		return datatypes.Dns_Domain_Registration{}, fmt.Errorf("Error looking up domain registration %s: %s", name, err)
+	}
+
+	if len(registrations) == 0 {
+		return datatypes.Dns_Domain_Registration{},
+			fmt.Errorf("No domain registration was found with the name '%s'", name)
+	}
+
+	return registrations[0], nil
+}
+
+// getDomainRegistrationBillingItem returns the billing item renewing the registration of a domain, or nil
+// when the domain has none.
+func getDomainRegistrationBillingItem(sess *session.Session, name string) (*datatypes.Billing_Item, error) {
+	items, err := services.GetAccountService(sess).
+		Filter(filter.Path("allTopLevelBillingItems.categoryCode").Eq(DomainRegistrationCategoryCode).Build()).
+		Mask("id,description,domainName,cancellationDate").
+		GetAllTopLevelBillingItems()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range items {
+		if sl.Get(item.Description, "") == name || sl.Get(item.DomainName, "") == name {
+			return &items[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// setDomainRegistrationAutoRenew cancels the renewal of a domain registration at its anniversary date. The
+// renewal can't be restored through the API once cancelled.
+func setDomainRegistrationAutoRenew(sess *session.Session, name string, autoRenew bool) error {
+	billingItem, err := getDomainRegistrationBillingItem(sess, name)
+	if err != nil {
+		return err
+	}
+
+	if billingItem == nil {
+		return fmt.Errorf("No billing item was found for domain %s", name)
+	}
+
+	renewing := billingItem.CancellationDate == nil
+	if renewing == autoRenew {
+		return nil
+	}
+
+	if autoRenew {
+		return fmt.Errorf("The renewal of domain %s was cancelled and can only be restored by SoftLayer support", name)
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelServiceOnAnniversaryDate()
+	return err
+}
+
+func flattenDomainRegistrationNameservers(nameservers []datatypes.Container_Dns_Domain_Registration_Nameserver) []interface{} {
+	names := make([]interface{}, 0)
+	for _, ns := range nameservers {
+		for _, n := range ns.Nameservers {
+			if n.Name != nil {
+				names = append(names, *n.Name)
+			}
+		}
+	}
+	return names
+}
+
+func expandStringList(list []interface{}) []string {
+	strings := make([]string, 0, len(list))
+	for _, v := range list {
+		strings = append(strings, v.(string))
+	}
+	sort.Strings(strings)
+	return strings
+}
+
+func expandDomainRegistrationContact(m map[string]interface{}) datatypes.Container_Dns_Domain_Registration_Contact {
+	contact := datatypes.Container_Dns_Domain_Registration_Contact{
+		Type:       sl.String(DomainRegistrationContactType),
+		FirstName:  sl.String(m["first_name"].(string)),
+		LastName:   sl.String(m["last_name"].(string)),
+		Address1:   sl.String(m["address1"].(string)),
+		City:       sl.String(m["city"].(string)),
+		PostalCode: sl.String(m["postal_code"].(string)),
+		Country:    sl.String(m["country"].(string)),
+		Email:      sl.String(m["email"].(string)),
+		Phone:      sl.String(m["phone"].(string)),
+	}
+
+	if v := m["organization_name"].(string); v != "" {
+		contact.OrganizationName = sl.String(v)
+	}
+	if v := m["address2"].(string); v != "" {
+		contact.Address2 = sl.String(v)
+	}
+	if v := m["address3"].(string); v != "" {
+		contact.Address3 = sl.String(v)
+	}
+	if v := m["state"].(string); v != "" {
+		contact.State = sl.String(v)
+	}
+	if v := m["fax"].(string); v != "" {
+		contact.Fax = sl.String(v)
+	}
+
+	return contact
+}
+
+func flattenDomainRegistrationContact(contact datatypes.Container_Dns_Domain_Registration_Contact) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"first_name":        sl.Get(contact.FirstName, ""),
+			"last_name":         sl.Get(contact.LastName, ""),
+			"organization_name": sl.Get(contact.OrganizationName, ""),
+			"address1":          sl.Get(contact.Address1, ""),
+			"address2":          sl.Get(contact.Address2, ""),
+			"address3":          sl.Get(contact.Address3, ""),
+			"city":              sl.Get(contact.City, ""),
+			"state":             sl.Get(contact.State, ""),
+			"postal_code":       sl.Get(contact.PostalCode, ""),
+			"country":           sl.Get(contact.Country, ""),
+			"email":             sl.Get(contact.Email, ""),
+			"phone":             sl.Get(contact.Phone, ""),
+			"fax":               sl.Get(contact.Fax, ""),
+		},
+	}
+}
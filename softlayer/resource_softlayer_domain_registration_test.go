@@ -0,0 +1,105 @@
+package softlayer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// The domain must be registered in the account before the test is run
+const testAccSoftLayerDomainRegistrationName = "tfacc-registered.com"
+
+func TestAccSoftLayerDomainRegistration_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDomainRegistrationConfig, testAccSoftLayerDomainRegistrationName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDomainRegistrationExists("softlayer_domain_registration.registration"),
+					resource.TestCheckResourceAttr(
+						"softlayer_domain_registration.registration", "name", testAccSoftLayerDomainRegistrationName),
+					resource.TestCheckResourceAttr(
+						"softlayer_domain_registration.registration", "nameservers.#", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_domain_registration.registration", "locked", "true"),
+					resource.TestMatchResourceAttr(
+						"softlayer_domain_registration.registration", "expire_date", regexp.MustCompile("^[0-9]{4}-")),
+					resource.TestMatchResourceAttr(
+						"softlayer_domain_registration.registration", "status", regexp.MustCompile(".+")),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDomainRegistrationConfig, testAccSoftLayerDomainRegistrationName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDomainRegistrationExists("softlayer_domain_registration.registration"),
+					resource.TestCheckResourceAttr(
+						"softlayer_domain_registration.registration", "locked", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenDomainRegistrationNameservers(t *testing.T) {
+	nameservers := []datatypes.Container_Dns_Domain_Registration_Nameserver{
+		{
+			Nameservers: []datatypes.Container_Dns_Domain_Registration_Nameserver_List{
+				{Name: sl.String("ns1.softlayer.com")},
+				{Name: sl.String("ns2.softlayer.com")},
+			},
+		},
+	}
+
+	names := flattenDomainRegistrationNameservers(nameservers)
+	if len(names) != 2 || names[0] != "ns1.softlayer.com" || names[1] != "ns2.softlayer.com" {
+		t.Errorf("Unexpected nameservers: %v", names)
+	}
+}
+
+func testAccCheckSoftLayerDomainRegistrationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		registrationId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetDnsDomainRegistrationService(testAccProvider.Meta().(*session.Session))
+		registration, err := service.Id(registrationId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(*registration.Id) != rs.Primary.ID {
+			return fmt.Errorf("Domain registration not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerDomainRegistrationConfig = `
+resource "softlayer_domain_registration" "registration" {
+    name = "%s"
+    nameservers = ["ns1.softlayer.com", "ns2.softlayer.com"]
+    locked = %t
+}
+`
@@ -0,0 +1,908 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	EnduranceStorageType   = "Endurance"
+	PerformanceStorageType = "Performance"
+
+	EnduranceStoragePackageType   = "ADDITIONAL_SERVICES_ENTERPRISE_STORAGE"
+	PerformanceStoragePackageType = "ADDITIONAL_SERVICES_PERFORMANCE_STORAGE"
+
+	storagePackageItemsMask = "id,capacity,description,units,keyName," +
+		"prices[id,categories[id,name,categoryCode],capacityRestrictionType," +
+		"capacityRestrictionMinimum,capacityRestrictionMaximum]"
+
+	fileStorageMask = "id,username,notes,capacityGb,iops,storageTierLevel,snapshotCapacityGb," +
+		"serviceResourceBackendIpAddress,serviceResource[datacenter[name]],storageType[keyName]," +
+		"allowedVirtualGuests[id],allowedHardware[id],allowedSubnets[id,networkIdentifier,cidr]," +
+		"allowedIpAddresses[id,ipAddress]"
+)
+
+// Endurance storage is ordered by IOPS per GB. Each value maps to the key name of the
+// tier level item and to the value used by SoftLayer for the capacity restrictions of
+// the storage space prices.
+var enduranceStorageTiers = map[float64]struct {
+	keyName string
+	level   int
+}{
+	0.25: {"LOW_INTENSITY_TIER", 100},
+	2:    {"READHEAVY_TIER", 200},
+	4:    {"WRITEHEAVY_TIER", 300},
+	10:   {"10_IOPS_PER_GB", 1000},
+}
+
+func resourceSoftLayerFileStorage() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerFileStorageCreate,
+		Read:     resourceSoftLayerFileStorageRead,
+		Update:   resourceSoftLayerFileStorageUpdate,
+		Delete:   resourceSoftLayerFileStorageDelete,
+		Exists:   resourceSoftLayerFileStorageExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					storageType := v.(string)
+					if storageType != EnduranceStorageType && storageType != PerformanceStorageType {
+						errs = append(errs, fmt.Errorf(
+							"storage type should be either '%s' or '%s'", EnduranceStorageType, PerformanceStorageType))
+					}
+					return
+				},
+			},
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"iops": {
+				Type:     schema.TypeFloat,
+				Required: true,
+			},
+			"snapshot_capacity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mountpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"allowed_virtual_guest_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set: func(v interface{}) int {
+					return v.(int)
+				},
+			},
+			"allowed_hardware_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set: func(v interface{}) int {
+					return v.(int)
+				},
+			},
+			"allowed_subnets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"allowed_ip_addresses": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerFileStorageCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	storageType := d.Get("type").(string)
+	capacity := d.Get("capacity").(int)
+	iops := d.Get("iops").(float64)
+
+	dc, err := location.GetDatacenterByName(sess, d.Get("datacenter").(string), "id")
+	if err != nil {
+		return fmt.Errorf("Error creating file storage: %s", err)
+	}
+
+	pkg, prices, err := findFileStoragePrices(sess, storageType, capacity, iops, false)
+	if err != nil {
+		return fmt.Errorf("Error creating file storage: %s", err)
+	}
+
+	productOrder := datatypes.Container_Product_Order{
+		PackageId: pkg.Id,
+		Location:  sl.String(strconv.Itoa(*dc.Id)),
+		Prices:    prices,
+		Quantity:  sl.Int(1),
+	}
+
+	var productOrderContainer interface{}
+	if storageType == EnduranceStorageType {
+		productOrderContainer = &datatypes.Container_Product_Order_Network_Storage_Enterprise{
+			Container_Product_Order: productOrder,
+		}
+	} else {
+		productOrderContainer = &datatypes.Container_Product_Order_Network_PerformanceStorage_Nfs{
+			Container_Product_Order_Network_PerformanceStorage: datatypes.Container_Product_Order_Network_PerformanceStorage{
+				Container_Product_Order: productOrder,
+			},
+		}
+	}
+
+	log.Println("[INFO] Creating file storage")
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of file storage: %s", err)
+	}
+
+	storage, err := findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of file storage: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *storage.Id))
+	log.Printf("[INFO] File Storage ID: %s", d.Id())
+
+	_, err = waitForStorageAvailable(sess, *storage.Id)
+	if err != nil {
+		return fmt.Errorf("Error waiting for file storage (%s) to become available: %s", d.Id(), err)
+	}
+
+	if snapshotCapacity, ok := d.GetOk("snapshot_capacity"); ok {
+		err = orderStorageSnapshotSpace(sess, *storage.Id, storageType, iops, snapshotCapacity.(int), false)
+		if err != nil {
+			return fmt.Errorf("Error ordering snapshot space for file storage: %s", err)
+		}
+	}
+
+	if notes, ok := d.GetOk("notes"); ok {
+		_, err = services.GetNetworkStorageService(sess).Id(*storage.Id).
+			EditObject(&datatypes.Network_Storage{Notes: sl.String(notes.(string))})
+		if err != nil {
+			return fmt.Errorf("Error updating file storage notes: %s", err)
+		}
+	}
+
+	err = updateStorageAllowedHosts(d, sess, *storage.Id)
+	if err != nil {
+		return fmt.Errorf("Error authorizing hosts on file storage: %s", err)
+	}
+
+	return resourceSoftLayerFileStorageRead(d, meta)
+}
+
+func resourceSoftLayerFileStorageRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid file storage ID, must be an integer: %s", err)
+	}
+
+	storage, err := service.Id(storageId).Mask(fileStorageMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving file storage: %s", err)
+	}
+
+	d.Set("id", *storage.Id)
+	d.Set("volume_name", *storage.Username)
+	d.Set("capacity", sl.Get(storage.CapacityGb, 0))
+	d.Set("notes", sl.Get(storage.Notes, ""))
+
+	snapshotCapacity, err := strconv.Atoi(sl.Get(storage.SnapshotCapacityGb, "0").(string))
+	if err == nil {
+		d.Set("snapshot_capacity", snapshotCapacity)
+	}
+
+	if storage.ServiceResource != nil && storage.ServiceResource.Datacenter != nil {
+		d.Set("datacenter", *storage.ServiceResource.Datacenter.Name)
+	}
+
+	if storage.StorageType != nil && strings.Contains(*storage.StorageType.KeyName, "ENDURANCE") {
+		d.Set("type", EnduranceStorageType)
+		for iops, tier := range enduranceStorageTiers {
+			if tier.keyName == sl.Get(storage.StorageTierLevel, "").(string) {
+				d.Set("iops", iops)
+				break
+			}
+		}
+	} else {
+		d.Set("type", PerformanceStorageType)
+		if storage.Iops != nil {
+			iops, err := strconv.ParseFloat(*storage.Iops, 64)
+			if err == nil {
+				d.Set("iops", iops)
+			}
+		}
+	}
+
+	if storage.ServiceResourceBackendIpAddress != nil {
+		d.Set("mountpoint",
+			fmt.Sprintf("%s:/%s/data01", *storage.ServiceResourceBackendIpAddress, *storage.Username))
+	}
+
+	virtualGuestIds := make([]int, 0, len(storage.AllowedVirtualGuests))
+	for _, guest := range storage.AllowedVirtualGuests {
+		virtualGuestIds = append(virtualGuestIds, *guest.Id)
+	}
+	d.Set("allowed_virtual_guest_ids", virtualGuestIds)
+
+	hardwareIds := make([]int, 0, len(storage.AllowedHardware))
+	for _, hw := range storage.AllowedHardware {
+		hardwareIds = append(hardwareIds, *hw.Id)
+	}
+	d.Set("allowed_hardware_ids", hardwareIds)
+
+	subnets := make([]string, 0, len(storage.AllowedSubnets))
+	for _, subnet := range storage.AllowedSubnets {
+		subnets = append(subnets, fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr))
+	}
+	d.Set("allowed_subnets", subnets)
+
+	ipAddresses := make([]string, 0, len(storage.AllowedIpAddresses))
+	for _, ipAddress := range storage.AllowedIpAddresses {
+		ipAddresses = append(ipAddresses, *ipAddress.IpAddress)
+	}
+	d.Set("allowed_ip_addresses", ipAddresses)
+
+	return nil
+}
+
+func resourceSoftLayerFileStorageUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid file storage ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("capacity") || d.HasChange("iops") {
+		oldCapacity, newCapacity := d.GetChange("capacity")
+		if newCapacity.(int) < oldCapacity.(int) {
+			return fmt.Errorf("The capacity of file storage %d cannot be reduced from %d GB to %d GB",
+				storageId, oldCapacity.(int), newCapacity.(int))
+		}
+
+		err = upgradeFileStorage(d, sess, storageId)
+		if err != nil {
+			return fmt.Errorf("Error upgrading file storage: %s", err)
+		}
+	}
+
+	if d.HasChange("snapshot_capacity") {
+		oldCapacity, newCapacity := d.GetChange("snapshot_capacity")
+		if newCapacity.(int) < oldCapacity.(int) {
+			return fmt.Errorf("The snapshot capacity of file storage %d cannot be reduced from %d GB to %d GB",
+				storageId, oldCapacity.(int), newCapacity.(int))
+		}
+
+		err = orderStorageSnapshotSpace(sess, storageId, d.Get("type").(string), d.Get("iops").(float64),
+			newCapacity.(int), oldCapacity.(int) > 0)
+		if err != nil {
+			return fmt.Errorf("Error ordering snapshot space for file storage: %s", err)
+		}
+	}
+
+	if d.HasChange("notes") {
+		_, err = service.Id(storageId).
+			EditObject(&datatypes.Network_Storage{Notes: sl.String(d.Get("notes").(string))})
+		if err != nil {
+			return fmt.Errorf("Error updating file storage notes: %s", err)
+		}
+	}
+
+	err = updateStorageAllowedHosts(d, sess, storageId)
+	if err != nil {
+		return fmt.Errorf("Error updating authorized hosts of file storage: %s", err)
+	}
+
+	return resourceSoftLayerFileStorageRead(d, meta)
+}
+
+func resourceSoftLayerFileStorageDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid file storage ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(storageId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting file storage: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelItem(
+		sl.Bool(true), sl.Bool(true), sl.String("No longer required"), sl.String("Please cancel this storage"),
+	)
+	if err != nil {
+		return fmt.Errorf("Error canceling the file storage (%d): %s", storageId, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerFileStorageExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid file storage ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(storageId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving file storage: %s", err)
+	}
+
+	return result.Id != nil && *result.Id == storageId, nil
+}
+
+// findFileStoragePrices returns the package and the prices required to order a file storage
+// volume of the given type, capacity and IOPS. When upgrade is set, the prices of the base
+// storage service are left out as only the space, IOPS and tier prices can be modified.
+func findFileStoragePrices(sess *session.Session, storageType string, capacity int, iops float64, upgrade bool) (
+	datatypes.Product_Package, []datatypes.Product_Item_Price, error) {
+
+	pkg, productItems, err := getStoragePackageItems(sess, storageType)
+	if err != nil {
+		return datatypes.Product_Package{}, nil, err
+	}
+
+	selectors, err := getStorageVolumeSelectors(storageType, false, capacity, iops, upgrade)
+	if err != nil {
+		return datatypes.Product_Package{}, nil, err
+	}
+
+	prices, err := selectStoragePrices(productItems, selectors)
+	if err != nil {
+		return datatypes.Product_Package{}, nil, err
+	}
+
+	return pkg, prices, nil
+}
+
+func getStoragePackageItems(sess *session.Session, storageType string) (
+	datatypes.Product_Package, []datatypes.Product_Item, error) {
+
+	var pkg datatypes.Product_Package
+	var err error
+
+	if storageType == EnduranceStorageType {
+		pkg, err = product.GetPackageByType(sess, EnduranceStoragePackageType)
+	} else {
+		pkg, err = product.GetPackageByType(sess, PerformanceStoragePackageType)
+	}
+	if err != nil {
+		return datatypes.Product_Package{}, nil, err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id, storagePackageItemsMask)
+	if err != nil {
+		return datatypes.Product_Package{}, nil, err
+	}
+
+	return pkg, productItems, nil
+}
+
+// getStorageVolumeSelectors returns the selectors of the prices making up a storage volume.
+// block selects the block (iSCSI) storage prices instead of the file (NFS) storage prices.
+func getStorageVolumeSelectors(storageType string, block bool, capacity int, iops float64, upgrade bool) (
+	[]storagePriceSelector, error) {
+
+	var selectors []storagePriceSelector
+
+	if storageType == EnduranceStorageType {
+		tier, ok := enduranceStorageTiers[iops]
+		if !ok {
+			return nil,
+				fmt.Errorf("%v is not a valid IOPS per GB value for endurance storage. Valid values are 0.25, 2, 4 and 10", iops)
+		}
+
+		if !upgrade {
+			protocolCategory := "storage_file"
+			if block {
+				protocolCategory = "storage_block"
+			}
+
+			selectors = append(selectors,
+				storagePriceSelector{categoryCode: "storage_service_enterprise"},
+				storagePriceSelector{categoryCode: protocolCategory},
+			)
+		}
+
+		selectors = append(selectors,
+			storagePriceSelector{categoryCode: "storage_tier_level", keyName: tier.keyName},
+			storagePriceSelector{
+				categoryCode:     "performance_storage_space",
+				capacity:         float64(capacity),
+				restrictionType:  "STORAGE_TIER_LEVEL",
+				restrictionValue: tier.level,
+			},
+		)
+	} else {
+		if !upgrade {
+			protocolCategory := "performance_storage_nfs"
+			if block {
+				protocolCategory = "performance_storage_iscsi"
+			}
+
+			selectors = append(selectors, storagePriceSelector{categoryCode: protocolCategory})
+		}
+
+		selectors = append(selectors,
+			storagePriceSelector{categoryCode: "performance_storage_space", capacity: float64(capacity)},
+			storagePriceSelector{
+				categoryCode:     "performance_storage_iops",
+				capacity:         iops,
+				restrictionType:  "STORAGE_SPACE",
+				restrictionValue: capacity,
+			},
+		)
+	}
+
+	return selectors, nil
+}
+
+// getStorageRestriction returns the capacity restriction applying to the snapshot space and
+// replication prices of a storage volume.
+func getStorageRestriction(storageType string, iops float64) (string, int) {
+	if storageType == EnduranceStorageType {
+		return "STORAGE_TIER_LEVEL", enduranceStorageTiers[iops].level
+	}
+	return "IOPS", int(iops)
+}
+
+// storagePriceSelector describes a storage price to select from the items of a storage package.
+// Empty fields are not used to filter the items or the prices.
+type storagePriceSelector struct {
+	categoryCode     string
+	keyName          string
+	capacity         float64
+	restrictionType  string
+	restrictionValue int
+}
+
+func (s storagePriceSelector) String() string {
+	desc := []string{s.categoryCode}
+	if s.keyName != "" {
+		desc = append(desc, s.keyName)
+	}
+	if s.capacity > 0 {
+		desc = append(desc, strconv.FormatFloat(s.capacity, 'f', -1, 64))
+	}
+	return strings.Join(desc, " ")
+}
+
+func selectStoragePrices(productItems []datatypes.Product_Item, selectors []storagePriceSelector) (
+	[]datatypes.Product_Item_Price, error) {
+
+	prices := make([]datatypes.Product_Item_Price, 0, len(selectors))
+
+	for _, selector := range selectors {
+		price, ok := selectStoragePrice(productItems, selector)
+		if !ok {
+			return nil, fmt.Errorf("No product items matching %s could be found", selector)
+		}
+		prices = append(prices, datatypes.Product_Item_Price{Id: price.Id})
+	}
+
+	return prices, nil
+}
+
+func selectStoragePrice(productItems []datatypes.Product_Item, selector storagePriceSelector) (
+	datatypes.Product_Item_Price, bool) {
+
+	for _, item := range productItems {
+		if selector.keyName != "" && sl.Get(item.KeyName, "").(string) != selector.keyName {
+			continue
+		}
+
+		if selector.capacity > 0 &&
+			(item.Capacity == nil || *item.Capacity != datatypes.Float64(selector.capacity)) {
+			continue
+		}
+
+		for _, price := range item.Prices {
+			if !storagePriceHasCategory(price, selector.categoryCode) {
+				continue
+			}
+
+			if selector.restrictionType != "" && !storagePriceAllows(price, selector.restrictionType, selector.restrictionValue) {
+				continue
+			}
+
+			return price, true
+		}
+	}
+
+	return datatypes.Product_Item_Price{}, false
+}
+
+func storagePriceHasCategory(price datatypes.Product_Item_Price, categoryCode string) bool {
+	for _, category := range price.Categories {
+		if category.CategoryCode != nil && *category.CategoryCode == categoryCode {
+			return true
+		}
+	}
+	return false
+}
+
+// storagePriceAllows checks the capacity restriction of a price. Prices without restriction
+// are allowed for any value.
+func storagePriceAllows(price datatypes.Product_Item_Price, restrictionType string, value int) bool {
+	if price.CapacityRestrictionType == nil {
+		return true
+	}
+
+	if *price.CapacityRestrictionType != restrictionType {
+		return false
+	}
+
+	min, err := strconv.Atoi(sl.Get(price.CapacityRestrictionMinimum, "0").(string))
+	if err != nil {
+		return false
+	}
+
+	max, err := strconv.Atoi(sl.Get(price.CapacityRestrictionMaximum, "0").(string))
+	if err != nil {
+		return false
+	}
+
+	return min <= value && value <= max
+}
+
+// upgradeFileStorage places a modification order with the prices matching the new capacity
+// and IOPS of the volume.
+func upgradeFileStorage(d *schema.ResourceData, sess *session.Session, storageId int) error {
+	storageType := d.Get("type").(string)
+	capacity := d.Get("capacity").(int)
+	iops := d.Get("iops").(float64)
+
+	pkg, prices, err := findFileStoragePrices(sess, storageType, capacity, iops, true)
+	if err != nil {
+		return err
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Storage_Modification{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+		},
+		VolumeId: sl.Int(storageId),
+	}
+
+	log.Printf("[INFO] Upgrading file storage %d", storageId)
+
+	_, err = services.GetProductOrderService(sess).PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForStorageAvailable(sess, storageId)
+	return err
+}
+
+// orderStorageSnapshotSpace orders the snapshot space of a storage volume. When upgrade is set,
+// the existing snapshot space is upgraded to the given capacity.
+func orderStorageSnapshotSpace(sess *session.Session, storageId int, storageType string, iops float64,
+	snapshotCapacity int, upgrade bool) error {
+
+	pkg, productItems, err := getStoragePackageItems(sess, storageType)
+	if err != nil {
+		return err
+	}
+
+	restrictionType, restrictionValue := getStorageRestriction(storageType, iops)
+	prices, err := selectStoragePrices(productItems, []storagePriceSelector{
+		{
+			categoryCode:     "storage_snapshot_space",
+			capacity:         float64(snapshotCapacity),
+			restrictionType:  restrictionType,
+			restrictionValue: restrictionValue,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+		},
+		VolumeId: sl.Int(storageId),
+	}
+
+	log.Printf("[INFO] Ordering %d GB of snapshot space for storage %d", snapshotCapacity, storageId)
+
+	if upgrade {
+		_, err = services.GetProductOrderService(sess).PlaceOrder(
+			&datatypes.Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace_Upgrade{
+				Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace: productOrderContainer,
+			}, sl.Bool(false))
+	} else {
+		_, err = services.GetProductOrderService(sess).PlaceOrder(&productOrderContainer, sl.Bool(false))
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForStorageAvailable(sess, storageId)
+	return err
+}
+
+// updateStorageAllowedHosts authorizes and revokes the hosts of a storage volume according to the
+// difference between the previous and the current configuration.
+func updateStorageAllowedHosts(d *schema.ResourceData, sess *session.Session, storageId int) error {
+	service := services.GetNetworkStorageService(sess).Id(storageId)
+
+	if d.HasChange("allowed_virtual_guest_ids") {
+		added, removed := getSetChanges(d, "allowed_virtual_guest_ids")
+
+		if len(removed) > 0 {
+			guests := make([]datatypes.Virtual_Guest, 0, len(removed))
+			for _, id := range removed {
+				guests = append(guests, datatypes.Virtual_Guest{Id: sl.Int(id.(int))})
+			}
+			if _, err := service.RemoveAccessFromVirtualGuestList(guests); err != nil {
+				return err
+			}
+		}
+
+		if len(added) > 0 {
+			guests := make([]datatypes.Virtual_Guest, 0, len(added))
+			for _, id := range added {
+				guests = append(guests, datatypes.Virtual_Guest{Id: sl.Int(id.(int))})
+			}
+			if _, err := service.AllowAccessFromVirtualGuestList(guests); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("allowed_hardware_ids") {
+		added, removed := getSetChanges(d, "allowed_hardware_ids")
+
+		if len(removed) > 0 {
+			hardware := make([]datatypes.Hardware, 0, len(removed))
+			for _, id := range removed {
+				hardware = append(hardware, datatypes.Hardware{Id: sl.Int(id.(int))})
+			}
+			if _, err := service.RemoveAccessFromHardwareList(hardware); err != nil {
+				return err
+			}
+		}
+
+		if len(added) > 0 {
+			hardware := make([]datatypes.Hardware, 0, len(added))
+			for _, id := range added {
+				hardware = append(hardware, datatypes.Hardware{Id: sl.Int(id.(int))})
+			}
+			if _, err := service.AllowAccessFromHardwareList(hardware); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("allowed_subnets") {
+		added, removed := getSetChanges(d, "allowed_subnets")
+
+		if len(removed) > 0 {
+			subnets, err := getSubnetTemplates(removed, sess)
+			if err != nil {
+				return err
+			}
+			if _, err := service.RemoveAccessFromSubnetList(subnets); err != nil {
+				return err
+			}
+		}
+
+		if len(added) > 0 {
+			subnets, err := getSubnetTemplates(added, sess)
+			if err != nil {
+				return err
+			}
+			if _, err := service.AllowAccessFromSubnetList(subnets); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("allowed_ip_addresses") {
+		added, removed := getSetChanges(d, "allowed_ip_addresses")
+
+		if len(removed) > 0 {
+			ipAddresses, err := getIpAddressTemplates(removed, sess)
+			if err != nil {
+				return err
+			}
+			if _, err := service.RemoveAccessFromIpAddressList(ipAddresses); err != nil {
+				return err
+			}
+		}
+
+		if len(added) > 0 {
+			ipAddresses, err := getIpAddressTemplates(added, sess)
+			if err != nil {
+				return err
+			}
+			if _, err := service.AllowAccessFromIpAddressList(ipAddresses); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getSetChanges returns the elements added to and removed from a set attribute.
+func getSetChanges(d *schema.ResourceData, key string) (added []interface{}, removed []interface{}) {
+	o, n := d.GetChange(key)
+	oldSet := o.(*schema.Set)
+	newSet := n.(*schema.Set)
+
+	return newSet.Difference(oldSet).List(), oldSet.Difference(newSet).List()
+}
+
+func getSubnetTemplates(subnets []interface{}, sess *session.Session) ([]datatypes.Network_Subnet, error) {
+	templates := make([]datatypes.Network_Subnet, 0, len(subnets))
+	for _, subnet := range subnets {
+		subnetId, err := getSubnetId(subnet.(string), sess)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, datatypes.Network_Subnet{Id: sl.Int(subnetId)})
+	}
+	return templates, nil
+}
+
+func getIpAddressTemplates(ipAddresses []interface{}, sess *session.Session) ([]datatypes.Network_Subnet_IpAddress, error) {
+	service := services.GetNetworkSubnetIpAddressService(sess)
+
+	templates := make([]datatypes.Network_Subnet_IpAddress, 0, len(ipAddresses))
+	for _, ipAddress := range ipAddresses {
+		ip, err := service.GetByIpAddress(sl.String(ipAddress.(string)))
+		if err != nil {
+			return nil, fmt.Errorf("Error looking up IP address %s: %s", ipAddress, err)
+		}
+		if ip.Id == nil {
+			return nil, fmt.Errorf("Unable to locate the IP address %s", ipAddress)
+		}
+		templates = append(templates, datatypes.Network_Subnet_IpAddress{Id: ip.Id})
+	}
+	return templates, nil
+}
+
+func findStorageByOrderId(sess *session.Session, orderId int) (datatypes.Network_Storage, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			storages, err := services.GetAccountService(sess).
+				Filter(filter.Path("networkStorage.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id").
+				GetNetworkStorage()
+			if err != nil {
+				return datatypes.Network_Storage{}, "", err
+			}
+
+			if len(storages) == 1 {
+				return storages[0], "complete", nil
+			} else if len(storages) == 0 {
+				return nil, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one storage for order %d, found %d", orderId, len(storages))
+			}
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Storage{}, err
+	}
+
+	if result, ok := pendingResult.(datatypes.Network_Storage); ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Storage{},
+		fmt.Errorf("Cannot find storage with order id '%d'", orderId)
+}
+
+// waitForStorageAvailable waits until the storage volume has been provisioned and has no
+// active transaction.
+func waitForStorageAvailable(sess *session.Session, storageId int) (interface{}, error) {
+	log.Printf("Waiting for storage (%d) to have zero active transactions", storageId)
+	service := services.GetNetworkStorageService(sess)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", "provisioning"},
+		Target:  []string{"available"},
+		Refresh: func() (interface{}, string, error) {
+			storage, err := service.Id(storageId).Mask("id,username,activeTransactionCount").GetObject()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return nil, "", fmt.Errorf("Error retrieving storage: %s", err)
+				}
+
+				return false, "retry", nil
+			}
+
+			if storage.Username == nil || sl.Get(storage.ActiveTransactionCount, uint(0)).(uint) > 0 {
+				return storage, "provisioning", nil
+			}
+
+			return storage, "available", nil
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
@@ -0,0 +1,148 @@
+package softlayer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerFileStorage_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFileStorageConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerFileStorageExists("softlayer_file_storage.fs_endurance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "type", "Endurance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "capacity", "20"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "iops", "0.25"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "datacenter", "dal06"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "allowed_virtual_guest_ids.#", "1"),
+					resource.TestMatchResourceAttr(
+						"softlayer_file_storage.fs_endurance", "mountpoint", regexp.MustCompile(`^.+:/.+/data01$`)),
+					testAccCheckSoftLayerFileStorageExists("softlayer_file_storage.fs_performance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_performance", "type", "Performance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_performance", "iops", "100"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFileStorageConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "capacity", "40"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "notes", "endurance file storage"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_endurance", "allowed_virtual_guest_ids.#", "0"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_performance", "iops", "200"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerFileStorageExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		storageId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageService(testAccProvider.Meta().(*session.Session))
+		foundStorage, err := service.Id(storageId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundStorage.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerFileStorageConfig_basic = `
+resource "softlayer_virtual_guest" "storagevm1" {
+    hostname = "storagevm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_file_storage" "fs_endurance" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 0.25
+    allowed_virtual_guest_ids = ["${softlayer_virtual_guest.storagevm1.id}"]
+}
+
+resource "softlayer_file_storage" "fs_performance" {
+    type = "Performance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 100
+}`
+
+const testAccCheckSoftLayerFileStorageConfig_updated = `
+resource "softlayer_virtual_guest" "storagevm1" {
+    hostname = "storagevm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_file_storage" "fs_endurance" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 40
+    iops = 0.25
+    notes = "endurance file storage"
+}
+
+resource "softlayer_file_storage" "fs_performance" {
+    type = "Performance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 200
+}`
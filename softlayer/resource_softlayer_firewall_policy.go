@@ -0,0 +1,349 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+var firewallRuleProtocols = []string{"tcp", "udp", "icmp", "gre", "pptp", "esp", "ah"}
+
+func resourceSoftLayerFirewallPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerFirewallPolicyCreate,
+		Read:     resourceSoftLayerFirewallPolicyRead,
+		Update:   resourceSoftLayerFirewallPolicyUpdate,
+		Delete:   resourceSoftLayerFirewallPolicyDelete,
+		Exists:   resourceSoftLayerFirewallPolicyExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"firewall_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+								action := v.(string)
+								if action != "permit" && action != "deny" {
+									errs = append(errs, errors.New(
+										"action should be either 'permit' or 'deny'"))
+								}
+								return
+							},
+						},
+						"src_ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"src_ip_cidr": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"dst_ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"dst_ip_cidr": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"dst_port_range_start": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"dst_port_range_end": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"protocol": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateFirewallRuleProtocol,
+						},
+						"notes": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerFirewallPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	firewallId := d.Get("firewall_id").(int)
+
+	err := applyVlanFirewallRules(sess, firewallId, d.Get("rules").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("Error creating firewall policy: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", firewallId))
+
+	return resourceSoftLayerFirewallPolicyRead(d, meta)
+}
+
+func resourceSoftLayerFirewallPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
+	}
+
+	aclId, err := getVlanFirewallAccessControlListId(sess, firewallId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving firewall policy: %s", err)
+	}
+
+	rules, err := services.GetNetworkFirewallAccessControlListService(sess).Id(aclId).GetRules()
+	if err != nil {
+		return fmt.Errorf("Error retrieving firewall policy rules: %s", err)
+	}
+
+	d.Set("firewall_id", firewallId)
+	d.Set("rules", flattenVlanFirewallRules(rules))
+
+	return nil
+}
+
+func resourceSoftLayerFirewallPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("rules") {
+		err = applyVlanFirewallRules(sess, firewallId, d.Get("rules").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error updating firewall policy: %s", err)
+		}
+	}
+
+	return resourceSoftLayerFirewallPolicyRead(d, meta)
+}
+
+func resourceSoftLayerFirewallPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
+	}
+
+	// The rules of a dedicated firewall can't be removed, they are restored to the default rule set instead
+	log.Printf("[INFO] Restoring the default rules of vlan firewall %d", firewallId)
+
+	_, err = services.GetNetworkVlanFirewallService(sess).Id(firewallId).RestoreDefaults()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting firewall policy: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerFirewallPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkVlanFirewallService(sess).Id(firewallId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving vlan firewall: %s", err)
+	}
+	return result.Id != nil && *result.Id == firewallId, nil
+}
+
+// getVlanFirewallAccessControlListId returns the ID of the inbound access control list of the outside
+// interface of a dedicated firewall, which holds the rules of the firewall.
+func getVlanFirewallAccessControlListId(sess *session.Session, firewallId int) (int, error) {
+	firewall, err := services.GetNetworkVlanFirewallService(sess).
+		Id(firewallId).
+		Mask("id,networkVlan[firewallInterfaces[name,firewallContextAccessControlLists[id,direction]]]").
+		GetObject()
+	if err != nil {
+		return 0, err
+	}
+
+	if firewall.NetworkVlan != nil {
+		for _, iface := range firewall.NetworkVlan.FirewallInterfaces {
+			if sl.Get(iface.Name, "") != "outside" {
+				continue
+			}
+			for _, acl := range iface.FirewallContextAccessControlLists {
+				if sl.Get(acl.Direction, "") == "in" && acl.Id != nil {
+					return *acl.Id, nil
+				}
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("Unable to find the access control list of vlan firewall %d", firewallId)
+}
+
+// applyVlanFirewallRules replaces the rules of a dedicated firewall and waits until they are applied
+func applyVlanFirewallRules(sess *session.Session, firewallId int, rules []interface{}) error {
+	aclId, err := getVlanFirewallAccessControlListId(sess, firewallId)
+	if err != nil {
+		return err
+	}
+
+	return submitFirewallUpdateRequest(sess, datatypes.Network_Firewall_Update_Request{
+		FirewallContextAccessControlListId: sl.Int(aclId),
+		Rules:                              expandFirewallUpdateRequestRules(rules),
+	})
+}
+
+// submitFirewallUpdateRequest creates a firewall update request and waits until it is applied
+func submitFirewallUpdateRequest(sess *session.Session, request datatypes.Network_Firewall_Update_Request) error {
+	service := services.GetNetworkFirewallUpdateRequestService(sess)
+
+	log.Printf("[INFO] Submitting a firewall update request with %d rules", len(request.Rules))
+
+	result, err := service.CreateObject(&request)
+	if err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			updateRequest, err := service.Id(*result.Id).Mask("id,applyDate").GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if updateRequest.ApplyDate == nil {
+				return updateRequest, "pending", nil
+			}
+			return updateRequest, "complete", nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for firewall update request %d to be applied: %s", *result.Id, err)
+	}
+
+	return nil
+}
+
+func validateFirewallRuleProtocol(v interface{}, k string) (ws []string, errs []error) {
+	protocol := v.(string)
+	for _, p := range firewallRuleProtocols {
+		if protocol == p {
+			return
+		}
+	}
+	errs = append(errs, fmt.Errorf("%q should be one of %v", k, firewallRuleProtocols))
+	return
+}
+
+// expandFirewallUpdateRequestRules converts configured rules to update request rules, ordered as configured
+func expandFirewallUpdateRequestRules(configured []interface{}) []datatypes.Network_Firewall_Update_Request_Rule {
+	rules := make([]datatypes.Network_Firewall_Update_Request_Rule, 0, len(configured))
+
+	for i, r := range configured {
+		ruleMap := r.(map[string]interface{})
+		rule := datatypes.Network_Firewall_Update_Request_Rule{
+			OrderValue:           sl.Int(i + 1),
+			Action:               sl.String(ruleMap["action"].(string)),
+			SourceIpAddress:      sl.String(ruleMap["src_ip_address"].(string)),
+			DestinationIpAddress: sl.String(ruleMap["dst_ip_address"].(string)),
+			Protocol:             sl.String(ruleMap["protocol"].(string)),
+			Version:              sl.Int(4),
+		}
+
+		if cidr, ok := ruleMap["src_ip_cidr"].(int); ok && cidr > 0 {
+			rule.SourceIpCidr = sl.Int(cidr)
+		}
+
+		if cidr, ok := ruleMap["dst_ip_cidr"].(int); ok && cidr > 0 {
+			rule.DestinationIpCidr = sl.Int(cidr)
+		}
+
+		if port := ruleMap["dst_port_range_start"].(int); port > 0 {
+			rule.DestinationPortRangeStart = sl.Int(port)
+		}
+
+		if port := ruleMap["dst_port_range_end"].(int); port > 0 {
+			rule.DestinationPortRangeEnd = sl.Int(port)
+		}
+
+		if notes := ruleMap["notes"].(string); notes != "" {
+			rule.Notes = sl.String(notes)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+type vlanFirewallRulesByOrder []datatypes.Network_Vlan_Firewall_Rule
+
+func (r vlanFirewallRulesByOrder) Len() int      { return len(r) }
+func (r vlanFirewallRulesByOrder) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r vlanFirewallRulesByOrder) Less(i, j int) bool {
+	return sl.Get(r[i].OrderValue, 0).(int) < sl.Get(r[j].OrderValue, 0).(int)
+}
+
+func flattenVlanFirewallRules(rules []datatypes.Network_Vlan_Firewall_Rule) []map[string]interface{} {
+	sort.Sort(vlanFirewallRulesByOrder(rules))
+
+	flattened := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		flattened = append(flattened, map[string]interface{}{
+			"action":               sl.Get(rule.Action, ""),
+			"src_ip_address":       sl.Get(rule.SourceIpAddress, ""),
+			"src_ip_cidr":          sl.Get(rule.SourceIpCidr, 0),
+			"dst_ip_address":       sl.Get(rule.DestinationIpAddress, ""),
+			"dst_ip_cidr":          sl.Get(rule.DestinationIpCidr, 0),
+			"dst_port_range_start": sl.Get(rule.DestinationPortRangeStart, 0),
+			"dst_port_range_end":   sl.Get(rule.DestinationPortRangeEnd, 0),
+			"protocol":             sl.Get(rule.Protocol, ""),
+			"notes":                sl.Get(rule.Notes, ""),
+		})
+	}
+
+	return flattened
+}
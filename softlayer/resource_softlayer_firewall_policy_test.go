@@ -0,0 +1,181 @@
+package softlayer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerFirewallPolicy_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFirewallPolicyConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_firewall_policy.test_policy", "rules.#", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_firewall_policy.test_policy", "rules.0.action", "permit"),
+					resource.TestCheckResourceAttr(
+						"softlayer_firewall_policy.test_policy", "rules.0.dst_port_range_start", "22"),
+					resource.TestCheckResourceAttr(
+						"softlayer_firewall_policy.test_policy", "rules.1.action", "deny"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerFirewallPolicyConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_firewall_policy.test_policy", "rules.#", "3"),
+					resource.TestCheckResourceAttr(
+						"softlayer_firewall_policy.test_policy", "rules.1.dst_port_range_start", "443"),
+					resource.TestCheckResourceAttr(
+						"softlayer_firewall_policy.test_policy", "rules.2.action", "deny"),
+				),
+			},
+		},
+	})
+}
+
+func TestFirewallPolicyRules(t *testing.T) {
+	configured := []interface{}{
+		map[string]interface{}{
+			"action":               "permit",
+			"src_ip_address":       "10.1.1.0",
+			"src_ip_cidr":          24,
+			"dst_ip_address":       "any",
+			"dst_ip_cidr":          0,
+			"dst_port_range_start": 22,
+			"dst_port_range_end":   22,
+			"protocol":             "tcp",
+			"notes":                "ssh",
+		},
+		map[string]interface{}{
+			"action":               "deny",
+			"src_ip_address":       "any",
+			"src_ip_cidr":          0,
+			"dst_ip_address":       "any",
+			"dst_ip_cidr":          0,
+			"dst_port_range_start": 0,
+			"dst_port_range_end":   0,
+			"protocol":             "icmp",
+			"notes":                "",
+		},
+	}
+
+	rules := expandFirewallUpdateRequestRules(configured)
+
+	if *rules[0].OrderValue != 1 || *rules[1].OrderValue != 2 {
+		t.Fatalf("Unexpected order of expanded rules %+v", rules)
+	}
+
+	if rules[1].DestinationPortRangeStart != nil || rules[1].SourceIpCidr != nil || rules[1].Notes != nil {
+		t.Fatalf("Unexpected expanded rule %+v", rules[1])
+	}
+
+	// The running rules are returned in any order
+	running := []datatypes.Network_Vlan_Firewall_Rule{}
+	for i := len(rules) - 1; i >= 0; i-- {
+		running = append(running, datatypes.Network_Vlan_Firewall_Rule{
+			OrderValue:                rules[i].OrderValue,
+			Action:                    rules[i].Action,
+			SourceIpAddress:           rules[i].SourceIpAddress,
+			SourceIpCidr:              rules[i].SourceIpCidr,
+			DestinationIpAddress:      rules[i].DestinationIpAddress,
+			DestinationIpCidr:         rules[i].DestinationIpCidr,
+			DestinationPortRangeStart: rules[i].DestinationPortRangeStart,
+			DestinationPortRangeEnd:   rules[i].DestinationPortRangeEnd,
+			Protocol:                  rules[i].Protocol,
+			Notes:                     rules[i].Notes,
+			Status:                    sl.String("ACTIVE"),
+		})
+	}
+
+	flattened := flattenVlanFirewallRules(running)
+	for i, rule := range flattened {
+		for key, value := range rule {
+			if !reflect.DeepEqual(configured[i].(map[string]interface{})[key], value) {
+				t.Fatalf("Unexpected %s of flattened rule %d: %v", key, i, value)
+			}
+		}
+	}
+}
+
+const testAccCheckSoftLayerFirewallPolicyConfig_basic = `
+resource "softlayer_vlan" "test_policy_vlan" {
+   name = "test_policy_vlan"
+   datacenter = "dal06"
+   type = "PUBLIC"
+   subnet_size = 8
+}
+
+resource "softlayer_vlan_firewall" "test_policy_firewall" {
+   vlan_id = "${softlayer_vlan.test_policy_vlan.id}"
+}
+
+resource "softlayer_firewall_policy" "test_policy" {
+   firewall_id = "${softlayer_vlan_firewall.test_policy_firewall.id}"
+   rules = {
+      action = "permit"
+      src_ip_address = "10.1.1.0"
+      src_ip_cidr = 24
+      dst_ip_address = "any"
+      dst_port_range_start = 22
+      dst_port_range_end = 22
+      protocol = "tcp"
+      notes = "Permit ssh"
+   }
+   rules = {
+      action = "deny"
+      src_ip_address = "any"
+      dst_ip_address = "any"
+      protocol = "icmp"
+   }
+}`
+
+const testAccCheckSoftLayerFirewallPolicyConfig_updated = `
+resource "softlayer_vlan" "test_policy_vlan" {
+   name = "test_policy_vlan"
+   datacenter = "dal06"
+   type = "PUBLIC"
+   subnet_size = 8
+}
+
+resource "softlayer_vlan_firewall" "test_policy_firewall" {
+   vlan_id = "${softlayer_vlan.test_policy_vlan.id}"
+}
+
+resource "softlayer_firewall_policy" "test_policy" {
+   firewall_id = "${softlayer_vlan_firewall.test_policy_firewall.id}"
+   rules = {
+      action = "permit"
+      src_ip_address = "10.1.1.0"
+      src_ip_cidr = 24
+      dst_ip_address = "any"
+      dst_port_range_start = 22
+      dst_port_range_end = 22
+      protocol = "tcp"
+      notes = "Permit ssh"
+   }
+   rules = {
+      action = "permit"
+      src_ip_address = "any"
+      dst_ip_address = "any"
+      dst_port_range_start = 443
+      dst_port_range_end = 443
+      protocol = "tcp"
+      notes = "Permit https"
+   }
+   rules = {
+      action = "deny"
+      src_ip_address = "any"
+      dst_ip_address = "any"
+      protocol = "icmp"
+   }
+}`
@@ -0,0 +1,300 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	GlobalLbItemKeyName = "GLOBAL_LOAD_BALANCER"
+
+	GlobalLbMask = "id,hostname,fallbackIp,notes,allowedNumberOfHosts,loadBalanceType[id,name]"
+)
+
+// The types of SoftLayer_Network_LoadBalancer_Global_Type, which has no service to look them up
+var globalLbTypeIds = map[string]int{
+	"round robin":          1,
+	"least connections":    2,
+	"weighted round robin": 3,
+}
+
+func resourceSoftLayerGlobalLb() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerGlobalLbCreate,
+		Read:     resourceSoftLayerGlobalLbRead,
+		Update:   resourceSoftLayerGlobalLbUpdate,
+		Delete:   resourceSoftLayerGlobalLbDelete,
+		Exists:   resourceSoftLayerGlobalLbExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if !strings.Contains(v.(string), ".") {
+						errs = append(errs, fmt.Errorf("%q must be a fully qualified host name: %s", k, v.(string)))
+					}
+					return
+				},
+			},
+			"load_balance_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "round robin",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if _, ok := globalLbTypeIds[strings.ToLower(v.(string))]; !ok {
+						errs = append(errs, fmt.Errorf(
+							"%q must be one of 'round robin', 'least connections' or 'weighted round robin': %s",
+							k, v.(string)))
+					}
+					return
+				},
+				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+					return strings.ToLower(o) == strings.ToLower(n)
+				},
+			},
+			"fallback_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"allowed_number_of_hosts": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerGlobalLbCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	productOrderContainer, err := buildGlobalLbProductOrderContainer(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating global load balancer: %s", err)
+	}
+
+	log.Println("[INFO] Creating global load balancer")
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of global load balancer: %s", err)
+	}
+
+	globalLb, err := findGlobalLbByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of global load balancer: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *globalLb.Id))
+
+	log.Printf("[INFO] Global load balancer ID: %s", d.Id())
+
+	return resourceSoftLayerGlobalLbUpdate(d, meta)
+}
+
+func resourceSoftLayerGlobalLbRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalAccountService(sess)
+
+	globalLbId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid global load balancer ID, must be an integer: %s", err)
+	}
+
+	globalLb, err := service.Id(globalLbId).Mask(GlobalLbMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving global load balancer: %s", err)
+	}
+
+	d.Set("id", *globalLb.Id)
+	d.Set("hostname", sl.Get(globalLb.Hostname, ""))
+	d.Set("fallback_ip", sl.Get(globalLb.FallbackIp, ""))
+	d.Set("notes", sl.Get(globalLb.Notes, ""))
+	d.Set("allowed_number_of_hosts", sl.Get(globalLb.AllowedNumberOfHosts, 0))
+
+	if globalLb.LoadBalanceType != nil {
+		d.Set("load_balance_type", strings.ToLower(sl.Get(globalLb.LoadBalanceType.Name, "").(string)))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerGlobalLbUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalAccountService(sess)
+
+	globalLbId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid global load balancer ID, must be an integer: %s", err)
+	}
+
+	globalLb := datatypes.Network_LoadBalancer_Global_Account{
+		LoadBalanceTypeId: sl.Int(globalLbTypeIds[strings.ToLower(d.Get("load_balance_type").(string))]),
+		FallbackIp:        sl.String(d.Get("fallback_ip").(string)),
+		Notes:             sl.String(d.Get("notes").(string)),
+	}
+
+	_, err = service.Id(globalLbId).EditObject(&globalLb)
+	if err != nil {
+		return fmt.Errorf("Error updating global load balancer: %s", err)
+	}
+
+	return resourceSoftLayerGlobalLbRead(d, meta)
+}
+
+func resourceSoftLayerGlobalLbDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalAccountService(sess)
+
+	globalLbId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid global load balancer ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(globalLbId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error while looking up billing item associated with the global load balancer: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error while looking up billing item associated with the global load balancer: No billing item for ID:%d", globalLbId)
+	}
+
+	success, err := services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful cancellation")
+	}
+
+	return nil
+}
+
+func resourceSoftLayerGlobalLbExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalAccountService(sess)
+
+	globalLbId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid global load balancer ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(globalLbId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving global load balancer: %s", err)
+	}
+	return result.Id != nil && *result.Id == globalLbId, nil
+}
+
+func buildGlobalLbProductOrderContainer(d *schema.ResourceData, sess *session.Session) (
+	*datatypes.Container_Product_Order_Network_LoadBalancer_Global, error) {
+
+	// The order takes the host and the domain of the load balanced host name separately
+	parts := strings.SplitN(d.Get("hostname").(string), ".", 2)
+
+	pkg, err := product.GetPackageByType(sess, AdditionalServicesPackageType)
+	if err != nil {
+		return nil, err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var globalLbItem *datatypes.Product_Item
+	for i, item := range productItems {
+		if item.KeyName != nil && *item.KeyName == GlobalLbItemKeyName && len(item.Prices) > 0 {
+			globalLbItem = &productItems[i]
+			break
+		}
+	}
+
+	if globalLbItem == nil {
+		return nil, fmt.Errorf("No product items matching %s could be found", GlobalLbItemKeyName)
+	}
+
+	return &datatypes.Container_Product_Order_Network_LoadBalancer_Global{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: globalLbItem.Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+		Hostname: sl.String(parts[0]),
+		Domain:   sl.String(parts[1]),
+	}, nil
+}
+
+func findGlobalLbByOrderId(sess *session.Session, orderId int) (datatypes.Network_LoadBalancer_Global_Account, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			globalLbs, err := services.GetAccountService(sess).
+				Filter(filter.Path("globalLoadBalancerAccounts.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id").
+				GetGlobalLoadBalancerAccounts()
+			if err != nil {
+				return datatypes.Network_LoadBalancer_Global_Account{}, "", err
+			}
+
+			if len(globalLbs) == 1 {
+				return globalLbs[0], "complete", nil
+			} else if len(globalLbs) == 0 {
+				return nil, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one global load balancer for order %d, found %d", orderId, len(globalLbs))
+			}
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_LoadBalancer_Global_Account{}, err
+	}
+
+	var result, ok = pendingResult.(datatypes.Network_LoadBalancer_Global_Account)
+
+	if ok {
+		return result, nil
+	}
+
+	return datatypes.Network_LoadBalancer_Global_Account{},
+		fmt.Errorf("Cannot find global load balancer with order id '%d'", orderId)
+}
@@ -0,0 +1,219 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const GlobalLbHostMask = "id,destinationIp,destinationPort,weight,healthCheck,enabled,status,location," +
+	"loadBalanceOrder,loadBalancerAccount[id]"
+
+func resourceSoftLayerGlobalLbHost() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerGlobalLbHostCreate,
+		Read:     resourceSoftLayerGlobalLbHostRead,
+		Update:   resourceSoftLayerGlobalLbHostUpdate,
+		Delete:   resourceSoftLayerGlobalLbHostDelete,
+		Exists:   resourceSoftLayerGlobalLbHostExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"global_lb_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"destination_ip": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"destination_port": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  80,
+			},
+			"weight": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+			},
+			"health_check": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "http",
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"load_balance_order": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Hosts have no createObject method, they are added by editing their global load balancer
+func resourceSoftLayerGlobalLbHostCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalAccountService(sess)
+
+	globalLbId := d.Get("global_lb_id").(int)
+	host := expandGlobalLbHost(d)
+
+	log.Printf("[INFO] Adding host %s to global load balancer %d", *host.DestinationIp, globalLbId)
+
+	_, err := service.Id(globalLbId).EditObject(&datatypes.Network_LoadBalancer_Global_Account{
+		Hosts: []datatypes.Network_LoadBalancer_Global_Host{host},
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating global load balancer host: %s", err)
+	}
+
+	hosts, err := service.Id(globalLbId).Mask("id,destinationIp,destinationPort").GetHosts()
+	if err != nil {
+		return fmt.Errorf("Error retrieving the hosts of global load balancer %d: %s", globalLbId, err)
+	}
+
+	for _, h := range hosts {
+		if sl.Get(h.DestinationIp, "") == *host.DestinationIp && sl.Get(h.DestinationPort, 0) == *host.DestinationPort {
+			d.SetId(fmt.Sprintf("%d", *h.Id))
+			break
+		}
+	}
+
+	if d.Id() == "" {
+		return fmt.Errorf("Error creating global load balancer host: host %s:%d was not added",
+			*host.DestinationIp, *host.DestinationPort)
+	}
+
+	log.Printf("[INFO] Global load balancer host ID: %s", d.Id())
+
+	return resourceSoftLayerGlobalLbHostRead(d, meta)
+}
+
+func resourceSoftLayerGlobalLbHostRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalHostService(sess)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid global load balancer host ID, must be an integer: %s", err)
+	}
+
+	host, err := service.Id(hostId).Mask(GlobalLbHostMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving global load balancer host: %s", err)
+	}
+
+	d.Set("id", *host.Id)
+	d.Set("destination_ip", sl.Get(host.DestinationIp, ""))
+	d.Set("destination_port", sl.Get(host.DestinationPort, 0))
+	d.Set("weight", sl.Get(host.Weight, 0))
+	d.Set("health_check", sl.Get(host.HealthCheck, ""))
+	d.Set("enabled", sl.Get(host.Enabled, 0).(int) == 1)
+	d.Set("status", sl.Get(host.Status, ""))
+	d.Set("location", sl.Get(host.Location, ""))
+	d.Set("load_balance_order", sl.Get(host.LoadBalanceOrder, 0))
+
+	if host.LoadBalancerAccount != nil {
+		d.Set("global_lb_id", sl.Get(host.LoadBalancerAccount.Id, 0))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerGlobalLbHostUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid global load balancer host ID, must be an integer: %s", err)
+	}
+
+	host := expandGlobalLbHost(d)
+	host.Id = sl.Int(hostId)
+
+	_, err = services.GetNetworkLoadBalancerGlobalAccountService(sess).
+		Id(d.Get("global_lb_id").(int)).
+		EditObject(&datatypes.Network_LoadBalancer_Global_Account{
+			Hosts: []datatypes.Network_LoadBalancer_Global_Host{host},
+		})
+	if err != nil {
+		return fmt.Errorf("Error updating global load balancer host: %s", err)
+	}
+
+	return resourceSoftLayerGlobalLbHostRead(d, meta)
+}
+
+func resourceSoftLayerGlobalLbHostDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalHostService(sess)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid global load balancer host ID, must be an integer: %s", err)
+	}
+
+	_, err = service.Id(hostId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting global load balancer host: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerGlobalLbHostExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkLoadBalancerGlobalHostService(sess)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid global load balancer host ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(hostId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving global load balancer host: %s", err)
+	}
+	return result.Id != nil && *result.Id == hostId, nil
+}
+
+func expandGlobalLbHost(d *schema.ResourceData) datatypes.Network_LoadBalancer_Global_Host {
+	enabled := 0
+	if d.Get("enabled").(bool) {
+		enabled = 1
+	}
+
+	return datatypes.Network_LoadBalancer_Global_Host{
+		DestinationIp:   sl.String(d.Get("destination_ip").(string)),
+		DestinationPort: sl.Int(d.Get("destination_port").(int)),
+		Weight:          sl.Int(d.Get("weight").(int)),
+		HealthCheck:     sl.String(d.Get("health_check").(string)),
+		Enabled:         sl.Int(enabled),
+	}
+}
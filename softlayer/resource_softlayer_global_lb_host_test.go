@@ -0,0 +1,99 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerGlobalLbHost_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerGlobalLbHostConfig, 1, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerGlobalLbHostExists("softlayer_global_lb_host.dal"),
+					testAccCheckSoftLayerGlobalLbHostExists("softlayer_global_lb_host.ams"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.dal", "destination_ip", "10.0.0.20"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.dal", "destination_port", "443"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.dal", "weight", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.dal", "enabled", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerGlobalLbHostConfig, 2, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerGlobalLbHostExists("softlayer_global_lb_host.dal"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.dal", "weight", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.dal", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerGlobalLbHostExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		hostId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkLoadBalancerGlobalHostService(testAccProvider.Meta().(*session.Session))
+		host, err := service.Id(hostId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(*host.Id) != rs.Primary.ID {
+			return fmt.Errorf("Global load balancer host not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerGlobalLbHostConfig = `
+resource "softlayer_global_lb" "test_global_lb" {
+    hostname = "gslb.tfacc-global-lb-host.com"
+    load_balance_type = "weighted round robin"
+}
+
+resource "softlayer_global_lb_host" "dal" {
+    global_lb_id = "${softlayer_global_lb.test_global_lb.id}"
+    destination_ip = "10.0.0.20"
+    destination_port = 443
+    weight = %d
+    health_check = "http"
+    enabled = %t
+}
+
+resource "softlayer_global_lb_host" "ams" {
+    global_lb_id = "${softlayer_global_lb.test_global_lb.id}"
+    destination_ip = "10.0.0.21"
+    destination_port = 443
+    weight = 1
+}
+`
@@ -0,0 +1,81 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerGlobalLb_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerGlobalLbConfig, "round robin", "10.0.0.10"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerGlobalLbExists("softlayer_global_lb.test_global_lb"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.test_global_lb", "hostname", "gslb.tfacc-global-lb.com"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.test_global_lb", "load_balance_type", "round robin"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.test_global_lb", "fallback_ip", "10.0.0.10"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerGlobalLbConfig, "least connections", "10.0.0.11"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerGlobalLbExists("softlayer_global_lb.test_global_lb"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.test_global_lb", "load_balance_type", "least connections"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.test_global_lb", "fallback_ip", "10.0.0.11"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerGlobalLbExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		globalLbId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkLoadBalancerGlobalAccountService(testAccProvider.Meta().(*session.Session))
+		globalLb, err := service.Id(globalLbId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(*globalLb.Id) != rs.Primary.ID {
+			return fmt.Errorf("Global load balancer not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerGlobalLbConfig = `
+resource "softlayer_global_lb" "test_global_lb" {
+    hostname = "gslb.tfacc-global-lb.com"
+    load_balance_type = "%s"
+    fallback_ip = "%s"
+}
+`
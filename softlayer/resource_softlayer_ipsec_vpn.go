@@ -0,0 +1,574 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	IpsecVpnItemKeyName = "IPSEC_STANDARD"
+
+	IpsecVpnMask = "id,name,datacenter[name],customerPeerIpAddress,internalPeerIpAddress,presharedKey," +
+		"phaseOneAuthentication,phaseOneEncryption,phaseOneDiffieHellmanGroup,phaseOneKeylife," +
+		"phaseTwoAuthentication,phaseTwoEncryption,phaseTwoDiffieHellmanGroup,phaseTwoKeylife,phaseTwoPerfectForwardSecrecy," +
+		"customerSubnets[id,networkIdentifier,cidr],internalSubnets[id],serviceSubnets[id]," +
+		"activeTransaction[transactionStatus[name]]"
+)
+
+func resourceSoftLayerIpsecVpn() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerIpsecVpnCreate,
+		Read:     resourceSoftLayerIpsecVpnRead,
+		Update:   resourceSoftLayerIpsecVpnUpdate,
+		Delete:   resourceSoftLayerIpsecVpnDelete,
+		Exists:   resourceSoftLayerIpsecVpnExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"remote_peer_ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"internal_peer_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"preshared_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"phase_one_authentication": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_one_encryption": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_one_diffie_hellman_group": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_one_keylife": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_two_authentication": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_two_encryption": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_two_diffie_hellman_group": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_two_keylife": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"phase_two_perfect_forward_secrecy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"customer_subnets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"internal_subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      func(v interface{}) int { return v.(int) },
+			},
+			"service_subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      func(v interface{}) int { return v.(int) },
+			},
+			"transaction_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerIpsecVpnCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	productOrderContainer, err := buildIpsecVpnProductOrderContainer(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating ipsec vpn: %s", err)
+	}
+
+	log.Println("[INFO] Creating ipsec vpn")
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of ipsec vpn: %s", err)
+	}
+
+	vpn, err := findIpsecVpnByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of ipsec vpn: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *vpn.Id))
+
+	return resourceSoftLayerIpsecVpnUpdate(d, meta)
+}
+
+func resourceSoftLayerIpsecVpnRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkTunnelModuleContextService(sess)
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ipsec vpn ID, must be an integer: %s", err)
+	}
+
+	vpn, err := service.Id(vpnId).Mask(IpsecVpnMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving ipsec vpn: %s", err)
+	}
+
+	d.Set("id", *vpn.Id)
+	d.Set("name", sl.Get(vpn.Name, ""))
+	d.Set("remote_peer_ip_address", sl.Get(vpn.CustomerPeerIpAddress, ""))
+	d.Set("internal_peer_ip_address", sl.Get(vpn.InternalPeerIpAddress, ""))
+	d.Set("preshared_key", sl.Get(vpn.PresharedKey, ""))
+	d.Set("phase_one_authentication", sl.Get(vpn.PhaseOneAuthentication, ""))
+	d.Set("phase_one_encryption", sl.Get(vpn.PhaseOneEncryption, ""))
+	d.Set("phase_one_diffie_hellman_group", sl.Get(vpn.PhaseOneDiffieHellmanGroup, 0))
+	d.Set("phase_one_keylife", sl.Get(vpn.PhaseOneKeylife, 0))
+	d.Set("phase_two_authentication", sl.Get(vpn.PhaseTwoAuthentication, ""))
+	d.Set("phase_two_encryption", sl.Get(vpn.PhaseTwoEncryption, ""))
+	d.Set("phase_two_diffie_hellman_group", sl.Get(vpn.PhaseTwoDiffieHellmanGroup, 0))
+	d.Set("phase_two_keylife", sl.Get(vpn.PhaseTwoKeylife, 0))
+	d.Set("phase_two_perfect_forward_secrecy", sl.Get(vpn.PhaseTwoPerfectForwardSecrecy, 0).(int) == 1)
+
+	if vpn.Datacenter != nil {
+		d.Set("datacenter", sl.Get(vpn.Datacenter.Name, ""))
+	}
+
+	customerSubnets := make([]interface{}, 0, len(vpn.CustomerSubnets))
+	for _, subnet := range vpn.CustomerSubnets {
+		customerSubnets = append(customerSubnets, fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr))
+	}
+	d.Set("customer_subnets", schema.NewSet(schema.HashString, customerSubnets))
+
+	d.Set("internal_subnet_ids", flattenSubnetIds(vpn.InternalSubnets))
+	d.Set("service_subnet_ids", flattenSubnetIds(vpn.ServiceSubnets))
+
+	// Tunnels report no status of their own, only the transaction which applies their configuration
+	transactionStatus := ""
+	if vpn.ActiveTransaction != nil && vpn.ActiveTransaction.TransactionStatus != nil {
+		transactionStatus = sl.Get(vpn.ActiveTransaction.TransactionStatus.Name, "").(string)
+	}
+	d.Set("transaction_status", transactionStatus)
+
+	return nil
+}
+
+func resourceSoftLayerIpsecVpnUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkTunnelModuleContextService(sess)
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ipsec vpn ID, must be an integer: %s", err)
+	}
+
+	// Parameters which are not configured keep the defaults of the tunnel
+	opts := datatypes.Network_Tunnel_Module_Context{}
+	if v, ok := d.GetOk("remote_peer_ip_address"); ok {
+		opts.CustomerPeerIpAddress = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("preshared_key"); ok {
+		opts.PresharedKey = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_one_authentication"); ok {
+		opts.PhaseOneAuthentication = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_one_encryption"); ok {
+		opts.PhaseOneEncryption = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_one_diffie_hellman_group"); ok {
+		opts.PhaseOneDiffieHellmanGroup = sl.Int(v.(int))
+	}
+	if v, ok := d.GetOk("phase_one_keylife"); ok {
+		opts.PhaseOneKeylife = sl.Int(v.(int))
+	}
+	if v, ok := d.GetOk("phase_two_authentication"); ok {
+		opts.PhaseTwoAuthentication = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_two_encryption"); ok {
+		opts.PhaseTwoEncryption = sl.String(v.(string))
+	}
+	if v, ok := d.GetOk("phase_two_diffie_hellman_group"); ok {
+		opts.PhaseTwoDiffieHellmanGroup = sl.Int(v.(int))
+	}
+	if v, ok := d.GetOk("phase_two_keylife"); ok {
+		opts.PhaseTwoKeylife = sl.Int(v.(int))
+	}
+	if v, ok := d.GetOk("phase_two_perfect_forward_secrecy"); ok || d.HasChange("phase_two_perfect_forward_secrecy") {
+		if v.(bool) {
+			opts.PhaseTwoPerfectForwardSecrecy = sl.Int(1)
+		} else {
+			opts.PhaseTwoPerfectForwardSecrecy = sl.Int(0)
+		}
+	}
+
+	_, err = service.Id(vpnId).EditObject(&opts)
+	if err != nil {
+		return fmt.Errorf("Error updating ipsec vpn: %s", err)
+	}
+
+	err = updateIpsecVpnCustomerSubnets(d, sess, vpnId)
+	if err != nil {
+		return fmt.Errorf("Error updating ipsec vpn customer subnets: %s", err)
+	}
+
+	if d.HasChange("internal_subnet_ids") {
+		add, remove := getSetChanges(d, "internal_subnet_ids")
+		for _, id := range remove {
+			_, err = service.Id(vpnId).RemovePrivateSubnetFromNetworkTunnel(sl.Int(id.(int)))
+			if err != nil {
+				return fmt.Errorf("Error removing internal subnet %d from ipsec vpn: %s", id.(int), err)
+			}
+		}
+		for _, id := range add {
+			_, err = service.Id(vpnId).AddPrivateSubnetToNetworkTunnel(sl.Int(id.(int)))
+			if err != nil {
+				return fmt.Errorf("Error adding internal subnet %d to ipsec vpn: %s", id.(int), err)
+			}
+		}
+	}
+
+	if d.HasChange("service_subnet_ids") {
+		add, remove := getSetChanges(d, "service_subnet_ids")
+		for _, id := range remove {
+			_, err = service.Id(vpnId).RemoveServiceSubnetFromNetworkTunnel(sl.Int(id.(int)))
+			if err != nil {
+				return fmt.Errorf("Error removing service subnet %d from ipsec vpn: %s", id.(int), err)
+			}
+		}
+		for _, id := range add {
+			_, err = service.Id(vpnId).AddServiceSubnetToNetworkTunnel(sl.Int(id.(int)))
+			if err != nil {
+				return fmt.Errorf("Error adding service subnet %d to ipsec vpn: %s", id.(int), err)
+			}
+		}
+	}
+
+	log.Printf("[INFO] Applying the configuration of ipsec vpn %d", vpnId)
+
+	_, err = service.Id(vpnId).ApplyConfigurationsToDevice()
+	if err != nil {
+		return fmt.Errorf("Error applying the configuration of ipsec vpn: %s", err)
+	}
+
+	err = waitForIpsecVpnTransaction(sess, vpnId)
+	if err != nil {
+		return fmt.Errorf("Error applying the configuration of ipsec vpn: %s", err)
+	}
+
+	return resourceSoftLayerIpsecVpnRead(d, meta)
+}
+
+func resourceSoftLayerIpsecVpnDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkTunnelModuleContextService(sess)
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ipsec vpn ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(vpnId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting ipsec vpn: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+
+	return err
+}
+
+func resourceSoftLayerIpsecVpnExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkTunnelModuleContextService(sess)
+
+	vpnId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ipsec vpn ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(vpnId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving ipsec vpn: %s", err)
+	}
+	return result.Id != nil && *result.Id == vpnId, nil
+}
+
+func buildIpsecVpnProductOrderContainer(d *schema.ResourceData, sess *session.Session) (
+	*datatypes.Container_Product_Order_Network_Tunnel_Ipsec, error) {
+
+	dc, err := location.GetDatacenterByName(sess, d.Get("datacenter").(string), "id")
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := product.GetPackageByType(sess, AdditionalServicesPackageType)
+	if err != nil {
+		return nil, err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var ipsecItem *datatypes.Product_Item
+	for i, item := range productItems {
+		if item.KeyName != nil && *item.KeyName == IpsecVpnItemKeyName && len(item.Prices) > 0 {
+			ipsecItem = &productItems[i]
+			break
+		}
+	}
+
+	if ipsecItem == nil {
+		return nil, fmt.Errorf("No product items matching %s could be found", IpsecVpnItemKeyName)
+	}
+
+	return &datatypes.Container_Product_Order_Network_Tunnel_Ipsec{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Location:  sl.String(strconv.Itoa(*dc.Id)),
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: ipsecItem.Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+	}, nil
+}
+
+func findIpsecVpnByOrderId(sess *session.Session, orderId int) (datatypes.Network_Tunnel_Module_Context, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			vpns, err := services.GetAccountService(sess).
+				Filter(filter.Path("networkTunnelContexts.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id").
+				GetNetworkTunnelContexts()
+			if err != nil {
+				return datatypes.Network_Tunnel_Module_Context{}, "", err
+			}
+
+			if len(vpns) == 1 {
+				return vpns[0], "complete", nil
+			} else if len(vpns) == 0 {
+				return nil, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one ipsec vpn for order %d, found %d", orderId, len(vpns))
+			}
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Tunnel_Module_Context{}, err
+	}
+
+	var result, ok = pendingResult.(datatypes.Network_Tunnel_Module_Context)
+
+	if ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Tunnel_Module_Context{},
+		fmt.Errorf("Cannot find ipsec vpn with order id '%d'", orderId)
+}
+
+func waitForIpsecVpnTransaction(sess *session.Session, vpnId int) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			vpn, err := services.GetNetworkTunnelModuleContextService(sess).
+				Id(vpnId).
+				Mask("id,activeTransaction[id]").
+				GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if vpn.ActiveTransaction != nil {
+				return vpn, "pending", nil
+			}
+			return vpn, "complete", nil
+		},
+		Timeout:    20 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
+// updateIpsecVpnCustomerSubnets adds and removes the remote subnets of a tunnel. The remote subnets
+// are created as customer subnets of the account before being added, and deleted once removed.
+func updateIpsecVpnCustomerSubnets(d *schema.ResourceData, sess *session.Session, vpnId int) error {
+	if !d.HasChange("customer_subnets") {
+		return nil
+	}
+
+	service := services.GetNetworkTunnelModuleContextService(sess)
+
+	current, err := service.Id(vpnId).Mask("id,networkIdentifier,cidr").GetCustomerSubnets()
+	if err != nil {
+		return err
+	}
+
+	currentIds := map[string]int{}
+	for _, subnet := range current {
+		currentIds[fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr)] = *subnet.Id
+	}
+
+	configured := map[string]bool{}
+	for _, s := range d.Get("customer_subnets").(*schema.Set).List() {
+		configured[s.(string)] = true
+	}
+
+	for subnet, id := range currentIds {
+		if configured[subnet] {
+			continue
+		}
+		_, err = service.Id(vpnId).RemoveCustomerSubnetFromNetworkTunnel(sl.Int(id))
+		if err != nil {
+			return fmt.Errorf("Error removing customer subnet %s: %s", subnet, err)
+		}
+
+		err = deleteCustomerSubnet(sess, id)
+		if err != nil {
+			return fmt.Errorf("Error deleting customer subnet %s: %s", subnet, err)
+		}
+	}
+
+	for subnet := range configured {
+		if _, ok := currentIds[subnet]; ok {
+			continue
+		}
+
+		subnetInfo := strings.Split(subnet, "/")
+		if len(subnetInfo) != 2 {
+			return fmt.Errorf("Unable to parse the provided subnet: %s", subnet)
+		}
+		cidr, err := strconv.Atoi(subnetInfo[1])
+		if err != nil {
+			return fmt.Errorf("Unable to parse the provided subnet: %s", subnet)
+		}
+
+		customerSubnet, err := services.GetNetworkCustomerSubnetService(sess).CreateObject(
+			&datatypes.Network_Customer_Subnet{
+				NetworkIdentifier: sl.String(subnetInfo[0]),
+				Cidr:              sl.Int(cidr),
+			})
+		if err != nil {
+			return fmt.Errorf("Error creating customer subnet %s: %s", subnet, err)
+		}
+
+		_, err = service.Id(vpnId).AddCustomerSubnetToNetworkTunnel(customerSubnet.Id)
+		if err != nil {
+			return fmt.Errorf("Error adding customer subnet %s: %s", subnet, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteCustomerSubnet deletes a customer subnet. softlayer-go has no deleteObject for customer subnets,
+// so the method is invoked with DoRequest directly.
+func deleteCustomerSubnet(sess *session.Session, id int) error {
+	var success bool
+	err := sess.DoRequest(
+		"SoftLayer_Network_Customer_Subnet",
+		"deleteObject",
+		nil,
+		&sl.Options{Id: &id},
+		&success,
+	)
+	if err == nil && !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful deletion of customer subnet %d", id)
+	}
+
+	return err
+}
+
+func flattenSubnetIds(subnets []datatypes.Network_Subnet) *schema.Set {
+	ids := make([]interface{}, 0, len(subnets))
+	for _, subnet := range subnets {
+		if subnet.Id != nil {
+			ids = append(ids, *subnet.Id)
+		}
+	}
+	return schema.NewSet(func(v interface{}) int { return v.(int) }, ids)
+}
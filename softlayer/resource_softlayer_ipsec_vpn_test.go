@@ -0,0 +1,100 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerIpsecVpn_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerIpsecVpnConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerIpsecVpnExists("softlayer_ipsec_vpn.test_vpn"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "datacenter", "dal06"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "remote_peer_ip_address", "203.0.113.10"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "phase_one_encryption", "aes256"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "phase_two_perfect_forward_secrecy", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "customer_subnets.#", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "transaction_status", ""),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerIpsecVpnConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "phase_one_encryption", "3des"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "phase_two_perfect_forward_secrecy", "false"),
+					resource.TestCheckResourceAttr(
+						"softlayer_ipsec_vpn.test_vpn", "customer_subnets.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerIpsecVpnExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		vpnId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkTunnelModuleContextService(testAccProvider.Meta().(*session.Session))
+		foundVpn, err := service.Id(vpnId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundVpn.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerIpsecVpnConfig_basic = `
+resource "softlayer_ipsec_vpn" "test_vpn" {
+   datacenter = "dal06"
+   remote_peer_ip_address = "203.0.113.10"
+   preshared_key = "tfacc-secret"
+   phase_one_encryption = "aes256"
+   phase_two_perfect_forward_secrecy = true
+   customer_subnets = ["192.168.100.0/24"]
+}`
+
+const testAccCheckSoftLayerIpsecVpnConfig_updated = `
+resource "softlayer_ipsec_vpn" "test_vpn" {
+   datacenter = "dal06"
+   remote_peer_ip_address = "203.0.113.10"
+   preshared_key = "tfacc-secret"
+   phase_one_encryption = "3des"
+   phase_two_perfect_forward_secrecy = false
+   customer_subnets = ["192.168.100.0/24", "192.168.101.0/24"]
+}`
@@ -23,10 +23,15 @@ const (
 
 	LbLocalPackageType = "ADDITIONAL_SERVICES_LOAD_BALANCER"
 
+	lbBillingItemUpgradeMask = "id,upgradeItems[id,keyName,capacity,prices[id,locationGroupId,categories[categoryCode]]]"
+
 	lbMask = "id,dedicatedFlag,connectionLimit,ipAddressId,securityCertificateId,highAvailabilityFlag," +
 		"sslEnabledFlag,loadBalancerHardware[datacenter[name]],ipAddress[ipAddress,subnetId]"
 )
 
+// The connection limits which can be ordered and upgraded to, in increasing order
+var lbLocalConnectionSteps = []int{250, 500, 1000, 1500, 2000, 2500, 3000, 4000, 5000, 15000, 150000}
+
 func resourceSoftLayerLbLocal() *schema.Resource {
 	return &schema.Resource{
 		Create:   resourceSoftLayerLbLocalCreate,
@@ -38,9 +43,9 @@ func resourceSoftLayerLbLocal() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"connections": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateLbLocalConnections,
 			},
 			"datacenter": {
 				Type:     schema.TypeString,
@@ -73,6 +78,7 @@ func resourceSoftLayerLbLocal() *schema.Resource {
 			},
 			"ssl_enabled": {
 				Type:     schema.TypeBool,
+				Optional: true,
 				Computed: true,
 			},
 		},
@@ -86,8 +92,6 @@ func resourceSoftLayerLbLocalCreate(d *schema.ResourceData, meta interface{}) er
 	haEnabled := d.Get("ha_enabled").(bool)
 	dedicated := d.Get("dedicated").(bool)
 
-	var categoryCode string
-
 	// SoftLayer capacities don't match the published capacities as seen in the local lb
 	// ordering screen in the customer portal. Terraform exposes the published capacities.
 	// Create a translation map for those cases where the published capacity does not
@@ -104,31 +108,23 @@ func resourceSoftLayerLbLocalCreate(d *schema.ResourceData, meta interface{}) er
 		capacity = c
 	}
 
-	var keyFormatter string
+	var categoryCode string
+	var sslEnabled bool
 	if dedicated {
 		// Dedicated local LB always comes with SSL support
-		d.Set("ssl_enabled", true)
+		sslEnabled = true
 		categoryCode = product.DedicatedLoadBalancerCategoryCode
-		if haEnabled {
-			keyFormatter = "DEDICATED_LOAD_BALANCER_WITH_HIGH_AVAILABILITY_AND_SSL_%d_CONNECTIONS"
-		} else {
-			keyFormatter = "LOAD_BALANCER_DEDICATED_WITH_SSL_OFFLOAD_%d_CONNECTIONS"
-		}
 	} else {
 		if d.Get("ha_enabled").(bool) {
 			return fmt.Errorf("High Availability is not supported for shared local load balancers")
 		}
+		_, hasCertificate := d.GetOk("security_certificate_id")
+		sslEnabled = hasCertificate || d.Get("ssl_enabled").(bool)
 		categoryCode = product.ProxyLoadBalancerCategoryCode
-		if _, ok := d.GetOk("security_certificate_id"); ok {
-			d.Set("ssl_enabled", true)
-			keyFormatter = "LOAD_BALANCER_%d_VIP_CONNECTIONS_WITH_SSL_OFFLOAD"
-		} else {
-			d.Set("ssl_enabled", false)
-			keyFormatter = "LOAD_BALANCER_%d_VIP_CONNECTIONS"
-		}
 	}
+	d.Set("ssl_enabled", sslEnabled)
 
-	keyName := fmt.Sprintf(keyFormatter, connections)
+	keyName := lbLocalItemKeyName(connections, dedicated, haEnabled, sslEnabled)
 
 	pkg, err := product.GetPackageByType(sess, LbLocalPackageType)
 	if err != nil {
@@ -202,6 +198,22 @@ func resourceSoftLayerLbLocalUpdate(d *schema.ResourceData, meta interface{}) er
 
 	certID := d.Get("security_certificate_id").(int)
 
+	// Create calls Update, so there is only something to upgrade when there is a previous connection limit
+	if oldConnections, newConnections := d.GetChange("connections"); oldConnections.(int) != 0 {
+		oldSsl, newSsl := d.GetChange("ssl_enabled")
+		orderSsl, err := checkLbLocalUpgrade(oldConnections.(int), newConnections.(int),
+			oldSsl.(bool), newSsl.(bool), certID)
+		if err != nil {
+			return fmt.Errorf("Upgrade load balancer failed: %s", err)
+		}
+
+		if orderSsl || d.HasChange("connections") {
+			if err := upgradeLbLocal(d, sess, vipID, newConnections.(int), orderSsl); err != nil {
+				return fmt.Errorf("Upgrade load balancer failed: %s", err)
+			}
+		}
+	}
+
 	err := setLocalLBSecurityCert(sess, vipID, certID)
 
 	if err != nil {
@@ -292,6 +304,201 @@ func resourceSoftLayerLbLocalExists(d *schema.ResourceData, meta interface{}) (b
 	return true, nil
 }
 
+// validateLbLocalConnections checks that connections is one of the connection limits of local load balancers
+func validateLbLocalConnections(v interface{}, k string) (ws []string, errs []error) {
+	connections := v.(int)
+	for _, step := range lbLocalConnectionSteps {
+		if connections == step {
+			return
+		}
+	}
+
+	errs = append(errs, fmt.Errorf("%q must be one of %v: %d", k, lbLocalConnectionSteps, connections))
+	return
+}
+
+// lbLocalItemKeyName returns the key name of the product item of a local load balancer
+func lbLocalItemKeyName(connections int, dedicated, haEnabled, sslEnabled bool) string {
+	var keyFormatter string
+	if dedicated {
+		if haEnabled {
+			keyFormatter = "DEDICATED_LOAD_BALANCER_WITH_HIGH_AVAILABILITY_AND_SSL_%d_CONNECTIONS"
+		} else {
+			keyFormatter = "LOAD_BALANCER_DEDICATED_WITH_SSL_OFFLOAD_%d_CONNECTIONS"
+		}
+	} else {
+		if sslEnabled {
+			keyFormatter = "LOAD_BALANCER_%d_VIP_CONNECTIONS_WITH_SSL_OFFLOAD"
+		} else {
+			keyFormatter = "LOAD_BALANCER_%d_VIP_CONNECTIONS"
+		}
+	}
+
+	return fmt.Sprintf(keyFormatter, connections)
+}
+
+// checkLbLocalUpgrade returns whether changing a local load balancer from the old to the new connection
+// limit, SSL setting and certificate requires ordering SSL offload, or an error if it is not an upgrade.
+// SoftLayer has no downgrade orders for local load balancers, and a certificate requires SSL offload, so
+// setting one on a shared load balancer without it orders SSL offload too.
+func checkLbLocalUpgrade(oldConnections, newConnections int, oldSsl, newSsl bool, certID int) (bool, error) {
+	if newConnections < oldConnections {
+		return false, fmt.Errorf("The connection limit of a local load balancer cannot be decreased from %d to %d",
+			oldConnections, newConnections)
+	}
+
+	if oldSsl && !newSsl {
+		return false, fmt.Errorf("SSL offload cannot be removed from a local load balancer")
+	}
+
+	return !oldSsl && (newSsl || certID != 0), nil
+}
+
+// upgradeLbLocal upgrades the connection limit and SSL offload of a local load balancer in place, which keeps
+// its virtual IP address. Connection limit increases use upgradeConnectionLimit, which moves to the next
+// higher limit of the same product. Enabling SSL offload requires a different product, which is ordered
+// from the upgrade items of the load balancer's billing item.
+func upgradeLbLocal(d *schema.ResourceData, sess *session.Session, vipID int, connections int, orderSsl bool) error {
+	if orderSsl {
+		return orderLbLocalUpgrade(d, sess, vipID, connections)
+	}
+
+	service := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualIpAddressService(sess)
+
+	for {
+		vip, err := service.Id(vipID).Mask("id,connectionLimit").GetObject()
+		if err != nil {
+			return err
+		}
+
+		connectionLimit := getConnectionLimit(*vip.ConnectionLimit)
+		if connectionLimit >= connections {
+			return nil
+		}
+
+		log.Printf("[INFO] Upgrading the connection limit of load balancer %d from %d", vipID, connectionLimit)
+
+		success, err := service.Id(vipID).UpgradeConnectionLimit()
+		if err != nil {
+			return err
+		}
+		if !success {
+			return fmt.Errorf("SoftLayer reported an unsuccessful connection limit upgrade")
+		}
+
+		_, err = waitForLbLocalUpgrade(sess, vipID, func(vip datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress) bool {
+			return getConnectionLimit(sl.Get(vip.ConnectionLimit, 0).(int)) > connectionLimit
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// orderLbLocalUpgrade places an upgrade order for the SSL offload product of a shared local load balancer
+// with the given connection limit
+func orderLbLocalUpgrade(d *schema.ResourceData, sess *session.Session, vipID int, connections int) error {
+	if d.Get("dedicated").(bool) {
+		return nil
+	}
+
+	vipService := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualIpAddressService(sess)
+
+	billingItem, err := vipService.Id(vipID).Mask(lbBillingItemUpgradeMask).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error while looking up billing item associated with the load balancer: %s", err)
+	}
+
+	keyName := lbLocalItemKeyName(connections, false, false, true)
+
+	var price *datatypes.Product_Item_Price
+	for _, item := range billingItem.UpgradeItems {
+		if sl.Get(item.KeyName, "").(string) != keyName {
+			continue
+		}
+		for i, p := range item.Prices {
+			// Use the standard price, which has no location group
+			if p.LocationGroupId == nil {
+				price = &item.Prices[i]
+				break
+			}
+		}
+	}
+
+	if price == nil {
+		return fmt.Errorf("Load balancer %d cannot be upgraded to %s", vipID, keyName)
+	}
+
+	pkg, err := product.GetPackageByType(sess, LbLocalPackageType)
+	if err != nil {
+		return err
+	}
+
+	dc, err := location.GetDatacenterByName(sess, d.Get("datacenter").(string))
+	if err != nil {
+		return err
+	}
+
+	// The order container of local load balancers has no field for the upgraded virtual IP address, so it
+	// is passed as an order property.
+	productOrderContainer := datatypes.Container_Product_Order_Network_LoadBalancer{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Location:  sl.String(strconv.Itoa(*dc.Id)),
+			Prices:    []datatypes.Product_Item_Price{{Id: price.Id}},
+			Quantity:  sl.Int(1),
+			Properties: []datatypes.Container_Product_Order_Property{
+				{
+					Name:  sl.String("virtualIpAddressId"),
+					Value: sl.String(strconv.Itoa(vipID)),
+				},
+			},
+		},
+	}
+
+	log.Printf("[INFO] Upgrading load balancer %d to %s", vipID, keyName)
+
+	_, err = services.GetProductOrderService(sess).PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during upgrade of load balancer: %s", err)
+	}
+
+	_, err = waitForLbLocalUpgrade(sess, vipID, func(vip datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress) bool {
+		return sl.Get(vip.SslEnabledFlag, false).(bool) &&
+			getConnectionLimit(sl.Get(vip.ConnectionLimit, 0).(int)) >= connections
+	})
+	return err
+}
+
+// waitForLbLocalUpgrade waits until upgraded reports that the upgrade of a local load balancer is complete
+func waitForLbLocalUpgrade(sess *session.Session, vipID int,
+	upgraded func(datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress) bool) (interface{}, error) {
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			vip, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualIpAddressService(sess).
+				Id(vipID).
+				Mask("id,connectionLimit,sslEnabledFlag").
+				GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if upgraded(vip) {
+				return vip, "complete", nil
+			}
+			return vip, "pending", nil
+		},
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
 /* When requesting 15000 SL creates between 15000 and 150000. When requesting 150000 SL creates >= 150000 */
 func getConnectionLimit(connectionLimit int) int {
 	if connectionLimit >= LB_LARGE_150000_CONNECTIONS {
@@ -316,12 +523,7 @@ func findLoadBalancerByOrderId(sess *session.Session, orderId int, dedicated boo
 		Pending: []string{"pending"},
 		Target:  []string{"complete"},
 		Refresh: func() (interface{}, string, error) {
-			lbs, err := services.GetAccountService(sess).
-				Filter(filter.Build(
-					filter.Path(filterPath).
-						Eq(strconv.Itoa(orderId)))).
-				Mask(lbMask).
-				GetAdcLoadBalancers()
+			lbs, err := getAdcLoadBalancers(sess, filterPath, strconv.Itoa(orderId), lbMask)
 			if err != nil {
 				return datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress{}, "", err
 			}
@@ -355,6 +557,18 @@ func findLoadBalancerByOrderId(sess *session.Session, orderId int, dedicated boo
 		fmt.Errorf("Cannot find Application Delivery Controller Load Balancer with order id '%d'", orderId)
 }
 
+// getAdcLoadBalancers returns the local load balancers of the account whose property at path equals value
+func getAdcLoadBalancers(sess *session.Session, path, value, mask string) (
+	[]datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress, error) {
+
+	return services.GetAccountService(sess).
+		Filter(filter.Build(
+			filter.Path(path).
+				Eq(value))).
+		Mask(mask).
+		GetAdcLoadBalancers()
+}
+
 func setLocalLBSecurityCert(sess *session.Session, vipID int, certID int) error {
 	var vip struct {
 		SecurityCertificateId *int `json:"securityCertificateId"`
@@ -46,6 +46,31 @@ func resourceSoftLayerLbLocalService() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"health_check": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"expected_response": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interval": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
 			"weight": {
 				Type:     schema.TypeInt,
 				Required: true,
@@ -76,8 +101,7 @@ func resourceSoftLayerLbLocalServiceCreate(d *schema.ResourceData, meta interfac
 	vsID := *serviceGroup.VirtualServer.Id
 	vipID := *serviceGroup.VirtualServer.VirtualIpAddress.Id
 
-	// Convert the health check type name to an ID
-	healthCheckTypeId, err := getHealthCheckTypeId(sess, d.Get("health_check_type").(string))
+	healthCheck, err := expandLbLocalHealthCheck(sess, d, nil)
 	if err != nil {
 		return err
 	}
@@ -102,9 +126,9 @@ func resourceSoftLayerLbLocalServiceCreate(d *schema.ResourceData, meta interfac
 					Port:        sl.Int(d.Get("port").(int)),
 					IpAddressId: sl.Int(d.Get("ip_address_id").(int)),
 
-					HealthChecks: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{{
-						HealthCheckTypeId: &healthCheckTypeId,
-					}},
+					HealthChecks: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{
+						healthCheck,
+					},
 
 					GroupReferences: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group_CrossReference{{
 						Weight: sl.Int(d.Get("weight").(int)),
@@ -150,7 +174,8 @@ func resourceSoftLayerLbLocalServiceUpdate(d *schema.ResourceData, meta interfac
 	svcID, _ := strconv.Atoi(d.Id())
 	svc, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerServiceService(sess).
 		Id(svcID).
-		Mask("id,serviceGroup[id,routingTypeId,routingMethodId,virtualServer[id,allocation,port,virtualIpAddress[id]]]").
+		Mask("id,healthChecks[id,healthCheckTypeId,attributes[id,healthAttributeTypeId]]," +
+			"serviceGroup[id,routingTypeId,routingMethodId,virtualServer[id,allocation,port,virtualIpAddress[id]]]").
 		GetObject()
 
 	if err != nil {
@@ -162,8 +187,13 @@ func resourceSoftLayerLbLocalServiceUpdate(d *schema.ResourceData, meta interfac
 	vsID := *svc.ServiceGroup.VirtualServer.Id
 	vipID := *svc.ServiceGroup.VirtualServer.VirtualIpAddress.Id
 
-	// Convert the health check type name to an ID
-	healthCheckTypeId, err := getHealthCheckTypeId(sess, d.Get("health_check_type").(string))
+	// Edit the current health check, if it has the same type
+	var currentHealthCheck *datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check
+	if len(svc.HealthChecks) > 0 {
+		currentHealthCheck = &svc.HealthChecks[0]
+	}
+
+	healthCheck, err := expandLbLocalHealthCheck(sess, d, currentHealthCheck)
 	if err != nil {
 		return err
 	}
@@ -189,9 +219,9 @@ func resourceSoftLayerLbLocalServiceUpdate(d *schema.ResourceData, meta interfac
 					Port:        sl.Int(d.Get("port").(int)),
 					IpAddressId: sl.Int(d.Get("ip_address_id").(int)),
 
-					HealthChecks: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{{
-						HealthCheckTypeId: &healthCheckTypeId,
-					}},
+					HealthChecks: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{
+						healthCheck,
+					},
 
 					GroupReferences: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group_CrossReference{{
 						Weight: sl.Int(d.Get("weight").(int)),
@@ -219,7 +249,7 @@ func resourceSoftLayerLbLocalServiceRead(d *schema.ResourceData, meta interface{
 
 	svc, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerServiceService(sess).
 		Id(svcID).
-		Mask("ipAddressId,enabled,port,healthChecks[type[keyname]],groupReferences[weight]").
+		Mask("ipAddressId,enabled,port,healthChecks[type[keyname],attributes[value,type[keyname]]],groupReferences[weight]").
 		GetObject()
 
 	if err != nil {
@@ -229,6 +259,7 @@ func resourceSoftLayerLbLocalServiceRead(d *schema.ResourceData, meta interface{
 	d.Set("ip_address_id", *svc.IpAddressId)
 	d.Set("port", *svc.Port)
 	d.Set("health_check_type", *svc.HealthChecks[0].Type.Keyname)
+	d.Set("health_check", flattenLbLocalHealthAttributes(svc.HealthChecks[0].Attributes))
 	d.Set("weight", *svc.GroupReferences[0].Weight)
 	d.Set("enabled", (*svc.Enabled == 1))
 
@@ -314,6 +345,140 @@ func getHealthCheckTypeId(sess *session.Session, healthCheckTypeName string) (in
 	return *healthCheckTypes[0].Id, nil
 }
 
+// The keynames of the health attribute types set by the health_check block
+var lbLocalHealthAttributeKeynames = map[string]string{
+	"path":              "LOCATION",
+	"expected_response": "EXPECTED_RESPONSE",
+	"interval":          "INTERVAL",
+	"timeout":           "TIMEOUT",
+}
+
+// expandLbLocalHealthCheck builds the health check of a service. The current health check is edited if it
+// has the same type and none of its attributes were removed, see matchLbLocalHealthCheck.
+func expandLbLocalHealthCheck(sess *session.Session, d *schema.ResourceData,
+	current *datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check) (
+	datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check, error) {
+
+	healthCheck := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{}
+
+	// Convert the health check type name to an ID
+	healthCheckTypeId, err := getHealthCheckTypeId(sess, d.Get("health_check_type").(string))
+	if err != nil {
+		return healthCheck, err
+	}
+	healthCheck.HealthCheckTypeId = &healthCheckTypeId
+
+	healthChecks := d.Get("health_check").([]interface{})
+	if len(healthChecks) > 0 && healthChecks[0] != nil {
+		attributes := healthChecks[0].(map[string]interface{})
+		for _, key := range []string{"path", "expected_response", "interval", "timeout"} {
+			value := fmt.Sprint(attributes[key])
+			if value == "" || value == "0" {
+				continue
+			}
+
+			attributeTypeId, err := getHealthAttributeTypeId(sess, lbLocalHealthAttributeKeynames[key])
+			if err != nil {
+				return healthCheck, err
+			}
+
+			healthCheck.Attributes = append(healthCheck.Attributes,
+				datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+					HealthAttributeTypeId: sl.Int(attributeTypeId),
+					Value:                 sl.String(value),
+				})
+		}
+	}
+
+	matchLbLocalHealthCheck(&healthCheck, current)
+
+	return healthCheck, nil
+}
+
+// matchLbLocalHealthCheck makes a health check edit the current one of a service, by setting the IDs of the
+// health check and of its attributes. The API can't delete the attributes of a health check, so when one
+// of them is removed the current health check is left without ID. It is then replaced by the new health
+// check, as when the type of the health check changes.
+func matchLbLocalHealthCheck(healthCheck *datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check,
+	current *datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check) {
+
+	if current == nil || current.HealthCheckTypeId == nil || healthCheck.HealthCheckTypeId == nil ||
+		*current.HealthCheckTypeId != *healthCheck.HealthCheckTypeId {
+		return
+	}
+
+	configured := map[int]bool{}
+	for _, attribute := range healthCheck.Attributes {
+		configured[sl.Get(attribute.HealthAttributeTypeId, 0).(int)] = true
+	}
+
+	currentIds := map[int]*int{}
+	for _, attribute := range current.Attributes {
+		attributeTypeId := sl.Get(attribute.HealthAttributeTypeId, 0).(int)
+		if !configured[attributeTypeId] {
+			log.Printf("[INFO] Replacing health check %d to remove its attribute %d",
+				sl.Get(current.Id, 0), sl.Get(attribute.Id, 0))
+			return
+		}
+		currentIds[attributeTypeId] = attribute.Id
+	}
+
+	healthCheck.Id = current.Id
+	for i, attribute := range healthCheck.Attributes {
+		healthCheck.Attributes[i].Id = currentIds[sl.Get(attribute.HealthAttributeTypeId, 0).(int)]
+	}
+}
+
+func flattenLbLocalHealthAttributes(
+	attributes []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute) []map[string]interface{} {
+
+	if len(attributes) == 0 {
+		return []map[string]interface{}{}
+	}
+
+	healthCheck := map[string]interface{}{}
+	for _, attribute := range attributes {
+		if attribute.Type == nil || attribute.Type.Keyname == nil {
+			continue
+		}
+
+		for key, keyname := range lbLocalHealthAttributeKeynames {
+			if keyname != *attribute.Type.Keyname {
+				continue
+			}
+
+			value := sl.Get(attribute.Value, "").(string)
+			if key == "interval" || key == "timeout" {
+				intValue, _ := strconv.Atoi(value)
+				healthCheck[key] = intValue
+			} else {
+				healthCheck[key] = value
+			}
+		}
+	}
+
+	return []map[string]interface{}{healthCheck}
+}
+
+func getHealthAttributeTypeId(sess *session.Session, healthAttributeTypeName string) (int, error) {
+	healthAttributeTypes, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerHealthAttributeTypeService(sess).
+		Mask("id").
+		Filter(filter.Build(
+			filter.Path("keyname").Eq(healthAttributeTypeName))).
+		Limit(1).
+		GetAllObjects()
+
+	if err != nil {
+		return -1, err
+	}
+
+	if len(healthAttributeTypes) < 1 {
+		return -1, fmt.Errorf("Invalid health attribute type: %s", healthAttributeTypeName)
+	}
+
+	return *healthAttributeTypes[0].Id, nil
+}
+
 func updateLoadBalancerService(sess *session.Session, vipID int, vip *datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress) error {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"pending"},
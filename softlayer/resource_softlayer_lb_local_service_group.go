@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -55,6 +56,21 @@ func resourceSoftLayerLbLocalServiceGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"persistence": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if _, ok := lbLocalPersistenceSuffixes[v.(string)]; !ok && v.(string) != "" {
+						errs = append(errs, fmt.Errorf("%q must be either 'source_ip' or 'cookie': %s", k, v.(string)))
+					}
+					return
+				},
+			},
+			"persistence_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -64,7 +80,12 @@ func resourceSoftLayerLbLocalServiceGroupCreate(d *schema.ResourceData, meta int
 
 	vipID := d.Get("load_balancer_id").(int)
 
-	routingMethodId, err := getRoutingMethodId(sess, d.Get("routing_method").(string))
+	routingMethod, err := lbLocalRoutingMethodKeyname(d.Get("routing_method").(string), d.Get("persistence").(string))
+	if err != nil {
+		return err
+	}
+
+	routingMethodId, err := getRoutingMethodId(sess, routingMethod)
 	if err != nil {
 		return err
 	}
@@ -83,6 +104,7 @@ func resourceSoftLayerLbLocalServiceGroupCreate(d *schema.ResourceData, meta int
 			ServiceGroups: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group{{
 				RoutingMethodId: &routingMethodId,
 				RoutingTypeId:   &routingTypeId,
+				Timeout:         expandLbLocalPersistenceTimeout(d),
 			}},
 		}},
 	}
@@ -127,7 +149,12 @@ func resourceSoftLayerLbLocalServiceGroupUpdate(d *schema.ResourceData, meta int
 	vsID, _ := strconv.Atoi(d.Id())
 	sgID := d.Get("service_group_id").(int)
 
-	routingMethodId, err := getRoutingMethodId(sess, d.Get("routing_method").(string))
+	routingMethod, err := lbLocalRoutingMethodKeyname(d.Get("routing_method").(string), d.Get("persistence").(string))
+	if err != nil {
+		return err
+	}
+
+	routingMethodId, err := getRoutingMethodId(sess, routingMethod)
 	if err != nil {
 		return err
 	}
@@ -148,6 +175,7 @@ func resourceSoftLayerLbLocalServiceGroupUpdate(d *schema.ResourceData, meta int
 				Id:              &sgID,
 				RoutingMethodId: &routingMethodId,
 				RoutingTypeId:   &routingTypeId,
+				Timeout:         expandLbLocalPersistenceTimeout(d),
 			}},
 		}},
 	}
@@ -176,7 +204,7 @@ func resourceSoftLayerLbLocalServiceGroupRead(d *schema.ResourceData, meta inter
 
 	vs, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualServerService(sess).
 		Id(vsID).
-		Mask("allocation,port,serviceGroups[routingMethod[keyname],routingType[keyname]]").
+		Mask("allocation,port,serviceGroups[timeout,routingMethod[keyname],routingType[keyname]]").
 		GetObject()
 
 	if err != nil {
@@ -186,7 +214,17 @@ func resourceSoftLayerLbLocalServiceGroupRead(d *schema.ResourceData, meta inter
 	d.Set("allocation", *vs.Allocation)
 	d.Set("port", *vs.Port)
 
-	d.Set("routing_method", *vs.ServiceGroups[0].RoutingMethod.Keyname)
+	// Routing methods with persistence are stored as a routing method and a persistence, unless the
+	// configuration names the routing method with persistence
+	routingMethod := *vs.ServiceGroups[0].RoutingMethod.Keyname
+	persistence := ""
+	if routingMethod != d.Get("routing_method").(string) {
+		routingMethod, persistence = splitLbLocalRoutingMethod(routingMethod)
+	}
+
+	d.Set("routing_method", routingMethod)
+	d.Set("persistence", persistence)
+	d.Set("persistence_timeout", sl.Get(vs.ServiceGroups[0].Timeout, 0))
 	d.Set("routing_type", *vs.ServiceGroups[0].RoutingType.Keyname)
 
 	return nil
@@ -225,6 +263,48 @@ func resourceSoftLayerLbLocalServiceGroupExists(d *schema.ResourceData, meta int
 	return true, nil
 }
 
+// The suffixes of the routing methods with persistence
+var lbLocalPersistenceSuffixes = map[string]string{
+	"source_ip": "PERSISTENT_IP",
+	"cookie":    "INSERT_COOKIE",
+}
+
+// lbLocalRoutingMethodKeyname returns the keyname of a routing method with persistence, such as
+// ROUND_ROBIN_INSERT_COOKIE
+func lbLocalRoutingMethodKeyname(routingMethod, persistence string) (string, error) {
+	if persistence == "" {
+		return routingMethod, nil
+	}
+
+	for _, suffix := range lbLocalPersistenceSuffixes {
+		if strings.HasSuffix(routingMethod, suffix) {
+			return "", fmt.Errorf("Routing method %s already has persistence, it cannot be combined with persistence %s",
+				routingMethod, persistence)
+		}
+	}
+
+	return routingMethod + "_" + lbLocalPersistenceSuffixes[persistence], nil
+}
+
+// splitLbLocalRoutingMethod splits the keyname of a routing method into the routing method and its persistence
+func splitLbLocalRoutingMethod(keyname string) (string, string) {
+	for persistence, suffix := range lbLocalPersistenceSuffixes {
+		if strings.HasSuffix(keyname, "_"+suffix) {
+			return strings.TrimSuffix(keyname, "_"+suffix), persistence
+		}
+	}
+	return keyname, ""
+}
+
+// expandLbLocalPersistenceTimeout returns the persistence timeout of a service group, or nil to keep the
+// default of the load balancer
+func expandLbLocalPersistenceTimeout(d *schema.ResourceData) *int {
+	if timeout, ok := d.GetOk("persistence_timeout"); ok {
+		return sl.Int(timeout.(int))
+	}
+	return nil
+}
+
 func getRoutingTypeId(sess *session.Session, routingTypeName string) (int, error) {
 	routingTypes, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerRoutingTypeService(sess).
 		Mask("id").
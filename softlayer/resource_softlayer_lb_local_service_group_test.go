@@ -1,8 +1,9 @@
 package softlayer
 
 import (
-	"github.com/hashicorp/terraform/helper/resource"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccSoftLayerLbLocalServiceGroup_Basic(t *testing.T) {
@@ -27,6 +28,54 @@ func TestAccSoftLayerLbLocalServiceGroup_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbLocalServiceGroup_Persistence(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbLocalServiceGroupConfig_persistence,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service_group.test_service_group", "routing_method", "ROUND_ROBIN"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service_group.test_service_group", "persistence", "cookie"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service_group.test_service_group", "persistence_timeout", "600"),
+				),
+			},
+		},
+	})
+}
+
+func TestLbLocalRoutingMethodKeyname(t *testing.T) {
+	keyname, err := lbLocalRoutingMethodKeyname("LEAST_CONNECTIONS", "source_ip")
+	if err != nil || keyname != "LEAST_CONNECTIONS_PERSISTENT_IP" {
+		t.Errorf("Unexpected routing method %s: %v", keyname, err)
+	}
+
+	if keyname, _ := lbLocalRoutingMethodKeyname("CONSISTENT_HASH_IP", ""); keyname != "CONSISTENT_HASH_IP" {
+		t.Errorf("Unexpected routing method without persistence: %s", keyname)
+	}
+
+	if _, err := lbLocalRoutingMethodKeyname("ROUND_ROBIN_INSERT_COOKIE", "cookie"); err == nil {
+		t.Errorf("Expected an error for a routing method which already has persistence")
+	}
+
+	cases := map[string][2]string{
+		"ROUND_ROBIN_INSERT_COOKIE":       {"ROUND_ROBIN", "cookie"},
+		"SHORTEST_RESPONSE_PERSISTENT_IP": {"SHORTEST_RESPONSE", "source_ip"},
+		"PERSISTENT_IP":                   {"PERSISTENT_IP", ""},
+		"CONSISTENT_HASH_IP":              {"CONSISTENT_HASH_IP", ""},
+	}
+	for keyname, expected := range cases {
+		routingMethod, persistence := splitLbLocalRoutingMethod(keyname)
+		if routingMethod != expected[0] || persistence != expected[1] {
+			t.Errorf("Expected %v for %s, got %s and %s", expected, keyname, routingMethod, persistence)
+		}
+	}
+}
+
 const testAccCheckSoftLayerLbLocalServiceGroupConfig_basic = `
 resource "softlayer_lb_local" "testacc_foobar_lb" {
     connections = 15000
@@ -42,3 +91,21 @@ resource "softlayer_lb_local_service_group" "test_service_group" {
     allocation = 100
 }
 `
+
+const testAccCheckSoftLayerLbLocalServiceGroupConfig_persistence = `
+resource "softlayer_lb_local" "testacc_foobar_lb" {
+    connections = 15000
+    datacenter    = "tok02"
+    ha_enabled  = false
+}
+
+resource "softlayer_lb_local_service_group" "test_service_group" {
+    port = 82
+    routing_method = "ROUND_ROBIN"
+    routing_type = "HTTP"
+    persistence = "cookie"
+    persistence_timeout = 600
+    load_balancer_id = "${softlayer_lb_local.testacc_foobar_lb.id}"
+    allocation = 100
+}
+`
@@ -1,8 +1,12 @@
 package softlayer
 
 import (
-	"github.com/hashicorp/terraform/helper/resource"
+	"fmt"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
 )
 
 func TestAccSoftLayerLbLocalService_Basic(t *testing.T) {
@@ -27,6 +31,117 @@ func TestAccSoftLayerLbLocalService_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbLocalService_HealthCheck(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbLocalServiceConfig_healthCheck("/health", 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service.test_service", "health_check_type", "HTTP-CUSTOM"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service.test_service", "health_check.0.path", "/health"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service.test_service", "health_check.0.expected_response", "OK"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service.test_service", "health_check.0.interval", "10"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbLocalServiceConfig_healthCheck("/status", 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service.test_service", "health_check.0.path", "/status"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service.test_service", "health_check.0.interval", "20"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenLbLocalHealthAttributes(t *testing.T) {
+	attribute := func(keyname, value string) datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute {
+		return datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+			Type:  &datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute_Type{Keyname: sl.String(keyname)},
+			Value: sl.String(value),
+		}
+	}
+
+	healthCheck := flattenLbLocalHealthAttributes(
+		[]datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+			attribute("LOCATION", "/health"),
+			attribute("EXPECTED_RESPONSE", "OK"),
+			attribute("INTERVAL", "10"),
+			attribute("UNKNOWN", "x"),
+		})
+
+	if len(healthCheck) != 1 || healthCheck[0]["path"] != "/health" || healthCheck[0]["expected_response"] != "OK" ||
+		healthCheck[0]["interval"] != 10 || len(healthCheck[0]) != 3 {
+		t.Errorf("Unexpected health check: %v", healthCheck)
+	}
+
+	if len(flattenLbLocalHealthAttributes(nil)) != 0 {
+		t.Errorf("Expected no health check without attributes")
+	}
+}
+
+func TestMatchLbLocalHealthCheck(t *testing.T) {
+	attribute := func(typeId int) datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute {
+		return datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+			HealthAttributeTypeId: sl.Int(typeId),
+		}
+	}
+	current := &datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{
+		Id:                sl.Int(7),
+		HealthCheckTypeId: sl.Int(21),
+		Attributes: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+			{Id: sl.Int(70), HealthAttributeTypeId: sl.Int(1)},
+			{Id: sl.Int(71), HealthAttributeTypeId: sl.Int(2)},
+		},
+	}
+
+	// Editing and adding attributes edits the current health check
+	edited := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{
+		HealthCheckTypeId: sl.Int(21),
+		Attributes: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+			attribute(1), attribute(2), attribute(3),
+		},
+	}
+	matchLbLocalHealthCheck(&edited, current)
+	if sl.Get(edited.Id, 0) != 7 || sl.Get(edited.Attributes[0].Id, 0) != 70 ||
+		sl.Get(edited.Attributes[1].Id, 0) != 71 || edited.Attributes[2].Id != nil {
+		t.Errorf("Expected the current health check and attributes to be edited, got %+v", edited)
+	}
+
+	// Removing an attribute replaces the current health check
+	removed := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{
+		HealthCheckTypeId: sl.Int(21),
+		Attributes: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+			attribute(1),
+		},
+	}
+	matchLbLocalHealthCheck(&removed, current)
+	if removed.Id != nil || removed.Attributes[0].Id != nil {
+		t.Errorf("Expected a new health check without the removed attribute, got %+v", removed)
+	}
+
+	noAttributes := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{HealthCheckTypeId: sl.Int(21)}
+	matchLbLocalHealthCheck(&noAttributes, current)
+	if noAttributes.Id != nil {
+		t.Errorf("Expected a new health check without attributes, got %+v", noAttributes)
+	}
+
+	// Changing the type replaces the current health check
+	retyped := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{HealthCheckTypeId: sl.Int(22)}
+	matchLbLocalHealthCheck(&retyped, current)
+	if retyped.Id != nil {
+		t.Errorf("Expected a new health check of the new type, got %+v", retyped)
+	}
+}
+
 const testAccCheckSoftLayerLbLocalServiceConfig_basic = `
 resource "softlayer_virtual_guest" "test_server_1" {
     hostname = "terraform-test"
@@ -68,3 +183,52 @@ resource "softlayer_lb_local_service" "test_service" {
     ip_address_id = "${softlayer_virtual_guest.test_server_1.ip_address_id}"
 }
 `
+
+func testAccCheckSoftLayerLbLocalServiceConfig_healthCheck(path string, interval int) string {
+	return fmt.Sprintf(`
+resource "softlayer_virtual_guest" "test_server_1" {
+    hostname = "terraform-test"
+    domain = "bar.example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "tok02"
+    network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_lb_local" "testacc_foobar_lb" {
+    connections = 250
+    datacenter    = "tok02"
+    ha_enabled  = false
+    dedicated = false
+}
+
+resource "softlayer_lb_local_service_group" "test_service_group" {
+    port = 82
+    routing_method = "ROUND_ROBIN"
+    routing_type = "HTTP"
+    load_balancer_id = "${softlayer_lb_local.testacc_foobar_lb.id}"
+    allocation = 100
+}
+
+resource "softlayer_lb_local_service" "test_service" {
+    port = 80
+    enabled = true
+    service_group_id = "${softlayer_lb_local_service_group.test_service_group.service_group_id}"
+    weight = 1
+    health_check_type = "HTTP-CUSTOM"
+    ip_address_id = "${softlayer_virtual_guest.test_server_1.ip_address_id}"
+
+    health_check {
+        path = "%s"
+        expected_response = "OK"
+        interval = %d
+        timeout = 5
+    }
+}
+`, path, interval)
+}
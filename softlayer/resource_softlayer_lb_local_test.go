@@ -1,8 +1,12 @@
 package softlayer
 
 import (
-	"github.com/hashicorp/terraform/helper/resource"
+	"fmt"
+	"regexp"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccSoftLayerLbLocalShared_Basic(t *testing.T) {
@@ -53,6 +57,132 @@ func TestAccSoftLayerLbLocalDedicated_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbLocalShared_Upgrade(t *testing.T) {
+	var ipAddress string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbLocalConfigShared_upgrade(250, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local.testacc_foobar_lb", "connections", "250"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local.testacc_foobar_lb", "ssl_enabled", "false"),
+					testAccCheckSoftLayerLbLocalIpAddress("softlayer_lb_local.testacc_foobar_lb", &ipAddress),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbLocalConfigShared_upgrade(1000, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local.testacc_foobar_lb", "connections", "1000"),
+					testAccCheckSoftLayerLbLocalIpAddress("softlayer_lb_local.testacc_foobar_lb", &ipAddress),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbLocalConfigShared_upgrade(1000, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local.testacc_foobar_lb", "ssl_enabled", "true"),
+					testAccCheckSoftLayerLbLocalIpAddress("softlayer_lb_local.testacc_foobar_lb", &ipAddress),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSoftLayerLbLocal_InvalidConnections(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccCheckSoftLayerLbLocalConfigShared_upgrade(300, false),
+				ExpectError: regexp.MustCompile("\"connections\" must be one of"),
+			},
+		},
+	})
+}
+
+func TestCheckLbLocalUpgrade(t *testing.T) {
+	if orderSsl, err := checkLbLocalUpgrade(250, 1000, false, true, 0); err != nil || !orderSsl {
+		t.Errorf("Expected an SSL offload order for an upgrade, got %t, %v", orderSsl, err)
+	}
+
+	if orderSsl, err := checkLbLocalUpgrade(1000, 1000, false, false, 0); err != nil || orderSsl {
+		t.Errorf("Expected no SSL offload order without changes, got %t, %v", orderSsl, err)
+	}
+
+	if orderSsl, err := checkLbLocalUpgrade(1000, 1000, true, true, 42); err != nil || orderSsl {
+		t.Errorf("Expected no SSL offload order with SSL offload, got %t, %v", orderSsl, err)
+	}
+
+	// A certificate on a shared load balancer without SSL offload would be unused otherwise
+	if orderSsl, err := checkLbLocalUpgrade(1000, 1000, false, false, 42); err != nil || !orderSsl {
+		t.Errorf("Expected an SSL offload order for a certificate, got %t, %v", orderSsl, err)
+	}
+
+	if _, err := checkLbLocalUpgrade(1000, 500, false, false, 0); err == nil {
+		t.Errorf("Expected an error for a decreased connection limit")
+	}
+
+	if _, err := checkLbLocalUpgrade(1000, 1000, true, false, 0); err == nil {
+		t.Errorf("Expected an error for a removed SSL offload")
+	}
+}
+
+func TestLbLocalItemKeyName(t *testing.T) {
+	cases := []struct {
+		connections                      int
+		dedicated, haEnabled, sslEnabled bool
+		expected                         string
+	}{
+		{250, false, false, false, "LOAD_BALANCER_250_VIP_CONNECTIONS"},
+		{1000, false, false, true, "LOAD_BALANCER_1000_VIP_CONNECTIONS_WITH_SSL_OFFLOAD"},
+		{15000, true, false, true, "LOAD_BALANCER_DEDICATED_WITH_SSL_OFFLOAD_15000_CONNECTIONS"},
+		{150000, true, true, true, "DEDICATED_LOAD_BALANCER_WITH_HIGH_AVAILABILITY_AND_SSL_150000_CONNECTIONS"},
+	}
+
+	for _, c := range cases {
+		if keyName := lbLocalItemKeyName(c.connections, c.dedicated, c.haEnabled, c.sslEnabled); keyName != c.expected {
+			t.Errorf("Expected %s, got %s", c.expected, keyName)
+		}
+	}
+}
+
+// testAccCheckSoftLayerLbLocalIpAddress records the IP address of a local load balancer on the first call and
+// checks that it has not changed on later calls
+func testAccCheckSoftLayerLbLocalIpAddress(n string, ipAddress *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		current := rs.Primary.Attributes["ip_address"]
+		if *ipAddress == "" {
+			*ipAddress = current
+		} else if *ipAddress != current {
+			return fmt.Errorf("The IP address of the load balancer changed from %s to %s", *ipAddress, current)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSoftLayerLbLocalConfigShared_upgrade(connections int, sslEnabled bool) string {
+	return fmt.Sprintf(`
+resource "softlayer_lb_local" "testacc_foobar_lb" {
+    connections = %d
+    datacenter    = "tok02"
+    ha_enabled  = false
+    ssl_enabled = %t
+}`, connections, sslEnabled)
+}
+
 const testAccCheckSoftLayerLbLocalConfigShared_basic = `
 resource "softlayer_lb_local" "testacc_foobar_lb" {
     connections = 250
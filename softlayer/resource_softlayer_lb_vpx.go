@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -23,12 +24,18 @@ import (
 const (
 	PACKAGE_ID_APPLICATION_DELIVERY_CONTROLLER = 192
 	DELIMITER                                  = "_"
+
+	// vpxHaStatusPartnerMissing is the HA status of a VPX whose HA partner no longer exists
+	vpxHaStatusPartnerMissing = "partner_missing"
+
+	vpxHaStatusTimeout = 10 * time.Second
 )
 
 func resourceSoftLayerLbVpx() *schema.Resource {
 	return &schema.Resource{
 		Create:   resourceSoftLayerLbVpxCreate,
 		Read:     resourceSoftLayerLbVpxRead,
+		Update:   resourceSoftLayerLbVpxUpdate,
 		Delete:   resourceSoftLayerLbVpxDelete,
 		Exists:   resourceSoftLayerLbVpxExists,
 		Importer: &schema.ResourceImporter{},
@@ -53,7 +60,6 @@ func resourceSoftLayerLbVpx() *schema.Resource {
 			"speed": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"version": {
@@ -65,13 +71,11 @@ func resourceSoftLayerLbVpx() *schema.Resource {
 			"plan": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"ip_count": {
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"public_vlan_id": {
@@ -136,6 +140,33 @@ func resourceSoftLayerLbVpx() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"management_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ha_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"ha_partner_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"ha_partner_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ha_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -190,17 +221,21 @@ func getPublicIpItemKeyName(ipCount int) string {
 	return strings.Join([]string{ipCountString, name}, DELIMITER)
 }
 
-func findVPXPriceItems(version string, speed int, plan string, ipCount int, meta interface{}) ([]datatypes.Product_Item_Price, error) {
+func getVPXProductItems(meta interface{}) ([]datatypes.Product_Item, error) {
 	sess := meta.(*session.Session)
 
 	// Get VPX package type.
 	productPackage, err := product.GetPackageByType(sess, "ADDITIONAL_SERVICES_APPLICATION_DELIVERY_APPLIANCE")
 	if err != nil {
-		return []datatypes.Product_Item_Price{}, err
+		return []datatypes.Product_Item{}, err
 	}
 
 	// Get VPX product items
-	items, err := product.GetPackageProducts(sess, *productPackage.Id)
+	return product.GetPackageProducts(sess, *productPackage.Id)
+}
+
+func findVPXPriceItems(version string, speed int, plan string, ipCount int, meta interface{}) ([]datatypes.Product_Item_Price, error) {
+	items, err := getVPXProductItems(meta)
 	if err != nil {
 		return []datatypes.Product_Item_Price{}, err
 	}
@@ -246,7 +281,34 @@ func findVPXPriceItems(version string, speed int, plan string, ipCount int, meta
 	}, nil
 }
 
+// findVPXUpgradePriceItem returns the price of the VPX item alone, which is what an upgrade order contains
+func findVPXUpgradePriceItem(version string, speed int, plan string, meta interface{}) (datatypes.Product_Item_Price, error) {
+	items, err := getVPXProductItems(meta)
+	if err != nil {
+		return datatypes.Product_Item_Price{}, err
+	}
+
+	nadcKey := getVPXPriceItemKeyName(version, speed, plan)
+	for _, item := range items {
+		if item.KeyName != nil && *item.KeyName == nadcKey && len(item.Prices) > 0 {
+			return datatypes.Product_Item_Price{Id: item.Prices[0].Id}, nil
+		}
+	}
+
+	return datatypes.Product_Item_Price{}, errors.New("VPX version, speed or plan have incorrect values")
+}
+
 func findVPXByOrderId(orderId int, meta interface{}) (datatypes.Network_Application_Delivery_Controller, error) {
+	vpxs, err := findVPXsByOrderId(orderId, 1, meta)
+	if err != nil {
+		return datatypes.Network_Application_Delivery_Controller{}, err
+	}
+
+	return vpxs[0], nil
+}
+
+// findVPXsByOrderId waits until all the VPXs of an order are provisioned. They are sorted by ID.
+func findVPXsByOrderId(orderId int, count int, meta interface{}) ([]datatypes.Network_Application_Delivery_Controller, error) {
 	service := services.GetAccountService(meta.(*session.Session))
 
 	stateConf := &resource.StateChangeConf{
@@ -260,15 +322,16 @@ func findVPXByOrderId(orderId int, meta interface{}) (datatypes.Network_Applicat
 					),
 				).GetApplicationDeliveryControllers()
 			if err != nil {
-				return datatypes.Network_Application_Delivery_Controller{}, "", err
+				return []datatypes.Network_Application_Delivery_Controller{}, "", err
 			}
 
-			if len(vpxs) == 1 {
-				return vpxs[0], "complete", nil
-			} else if len(vpxs) == 0 {
+			if len(vpxs) == count {
+				sort.Sort(vpxsById(vpxs))
+				return vpxs, "complete", nil
+			} else if len(vpxs) < count {
 				return nil, "pending", nil
 			} else {
-				return nil, "", fmt.Errorf("Expected one VPX: %s", err)
+				return nil, "", fmt.Errorf("Expected %d VPXs, found %d", count, len(vpxs))
 			}
 		},
 		Timeout:    10 * time.Minute,
@@ -279,19 +342,25 @@ func findVPXByOrderId(orderId int, meta interface{}) (datatypes.Network_Applicat
 	pendingResult, err := stateConf.WaitForState()
 
 	if err != nil {
-		return datatypes.Network_Application_Delivery_Controller{}, err
+		return []datatypes.Network_Application_Delivery_Controller{}, err
 	}
 
-	var result, ok = pendingResult.(datatypes.Network_Application_Delivery_Controller)
+	var result, ok = pendingResult.([]datatypes.Network_Application_Delivery_Controller)
 
 	if ok {
 		return result, nil
 	}
 
-	return datatypes.Network_Application_Delivery_Controller{},
+	return []datatypes.Network_Application_Delivery_Controller{},
 		fmt.Errorf("Cannot find Application Delivery Controller with order id '%d'", orderId)
 }
 
+type vpxsById []datatypes.Network_Application_Delivery_Controller
+
+func (v vpxsById) Len() int           { return len(v) }
+func (v vpxsById) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+func (v vpxsById) Less(i, j int) bool { return *v[i].Id < *v[j].Id }
+
 func prepareHardwareOptions(d *schema.ResourceData, meta interface{}) ([]datatypes.Hardware, error) {
 	hardwareOpts := make([]datatypes.Hardware, 1)
 	publicVlanId := d.Get("public_vlan_id").(int)
@@ -341,12 +410,17 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 	sess := meta.(*session.Session)
 
 	productOrderService := services.GetProductOrderService(sess)
-	NADCService := services.GetNetworkApplicationDeliveryControllerService(sess)
 	var err error
 
+	// A HA pair is ordered as two VPXs, which are paired once they are provisioned
+	quantity := 1
+	if d.Get("ha_enabled").(bool) {
+		quantity = 2
+	}
+
 	opts := datatypes.Container_Product_Order{
 		PackageId: sl.Int(PACKAGE_ID_APPLICATION_DELIVERY_CONTROLLER),
-		Quantity:  sl.Int(1),
+		Quantity:  sl.Int(quantity),
 	}
 
 	opts.Prices, err = findVPXPriceItems(
@@ -375,6 +449,10 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Error Cannot get hardware options '%s'.", err)
 	}
 
+	for len(opts.Hardware) < quantity {
+		opts.Hardware = append(opts.Hardware, opts.Hardware[0])
+	}
+
 	log.Println("[INFO] Creating network application delivery controller")
 
 	receipt, err := productOrderService.PlaceOrder(&opts, sl.Bool(false))
@@ -384,21 +462,45 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// Wait VPX provisioning
-	VPX, err := findVPXByOrderId(*receipt.OrderId, meta)
+	VPXs, err := findVPXsByOrderId(*receipt.OrderId, quantity, meta)
 
 	if err != nil {
 		return fmt.Errorf("Error creating network application delivery controller: %s", err)
 	}
 
-	d.SetId(fmt.Sprintf("%d", *VPX.Id))
+	d.SetId(fmt.Sprintf("%d", *VPXs[0].Id))
 
 	log.Printf("[INFO] Netscaler VPX ID: %s", d.Id())
 
-	id, err := strconv.Atoi(d.Id())
-	if err != nil {
-		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	if quantity == 2 {
+		d.Set("ha_partner_id", *VPXs[1].Id)
+		log.Printf("[INFO] Netscaler VPX HA partner ID: %d", *VPXs[1].Id)
+	}
+
+	for _, VPX := range VPXs {
+		err = waitForVpxReady(*VPX.Id, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Wait additional buffer time for VPX service.
+	time.Sleep(time.Second * 60)
+
+	if quantity == 2 {
+		err = pairVpxHaNodes(sess, *VPXs[0].Id, *VPXs[1].Id)
+		if err != nil {
+			return fmt.Errorf("Error pairing Netscaler VPX ID %d with %d: %s", *VPXs[0].Id, *VPXs[1].Id, err)
+		}
 	}
 
+	return resourceSoftLayerLbVpxRead(d, meta)
+}
+
+// waitForVpxReady waits until the VIPs of a VPX are provisioned and its REST service is available
+func waitForVpxReady(id int, meta interface{}) error {
+	NADCService := services.GetNetworkApplicationDeliveryControllerService(meta.(*session.Session))
+
 	// Wait Virtual IP provisioning
 	IsVipReady := false
 
@@ -444,10 +546,49 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Failed to intialize VPX REST Service for Netscaler VPX ID: %d", id)
 	}
 
-	// Wait additional buffer time for VPX service.
-	time.Sleep(time.Second * 60)
+	return nil
+}
 
-	return resourceSoftLayerLbVpxRead(d, meta)
+// pairVpxHaNodes configures two VPXs as the nodes of a HA pair through the NITRO API. Both nodes use the
+// password of the first one for their RPC nodes, so that they can synchronize their configuration.
+func pairVpxHaNodes(sess *session.Session, id int, partnerId int) error {
+	service := services.GetNetworkApplicationDeliveryControllerService(sess)
+	nodes := make([]datatypes.Network_Application_Delivery_Controller, 0, 2)
+
+	for _, nadcId := range []int{id, partnerId} {
+		nadc, err := service.Id(nadcId).Mask("id,managementIpAddress,password[username,password]").GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving network application delivery controller: %s", err)
+		}
+		if nadc.ManagementIpAddress == nil || nadc.Password == nil {
+			return fmt.Errorf("No management IP address or credentials for Netscaler VPX ID: %d", nadcId)
+		}
+		nodes = append(nodes, nadc)
+	}
+
+	rpcPassword := sl.Get(nodes[0].Password.Password, "").(string)
+
+	for i, node := range nodes {
+		peer := nodes[1-i]
+		client := newNitroClient(*node.ManagementIpAddress,
+			sl.Get(node.Password.Username, "").(string), sl.Get(node.Password.Password, "").(string))
+
+		for _, ipAddress := range []string{*node.ManagementIpAddress, *peer.ManagementIpAddress} {
+			if err := client.setRpcNodePassword(ipAddress, rpcPassword); err != nil {
+				return err
+			}
+		}
+
+		if err := client.addHaNode(1, *peer.ManagementIpAddress); err != nil {
+			return err
+		}
+
+		if err := client.saveConfig(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func resourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error {
@@ -461,7 +602,7 @@ func resourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error
 
 	getObjectResult, err := service.
 		Id(id).
-		Mask("id,name,type[name],datacenter,networkVlans[primaryRouter],networkVlans[primarySubnets],subnets[ipAddresses],description").
+		Mask("id,name,type[name],datacenter,managementIpAddress,primaryIpAddress,networkVlans[primaryRouter],networkVlans[primarySubnets],subnets[ipAddresses,endPointIpAddress[ipAddress]],description").
 		GetObject()
 
 	if err != nil {
@@ -470,6 +611,7 @@ func resourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error
 
 	d.Set("name", *getObjectResult.Name)
 	d.Set("type", *getObjectResult.Type.Name)
+	d.Set("management_ip_address", sl.Get(getObjectResult.ManagementIpAddress, ""))
 	if getObjectResult.Datacenter != nil {
 		d.Set("datacenter", *getObjectResult.Datacenter.Name)
 	}
@@ -503,45 +645,376 @@ func resourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error
 	}
 
 	vips := make([]string, 0)
-	ipCount := 0
-	for i, subnet := range getObjectResult.Subnets {
+	for _, subnet := range getObjectResult.Subnets {
 		for _, ipAddressObj := range subnet.IpAddresses {
 			vips = append(vips, *ipAddressObj.IpAddress)
-			if i == 0 {
-				ipCount++
-			}
 		}
 	}
 
 	d.Set("vip_pool", vips)
-	d.Set("ip_count", ipCount)
+	d.Set("ip_count", getVpxIpCount(getObjectResult.Subnets, sl.Get(getObjectResult.PrimaryIpAddress, "").(string)))
 
-	description := *getObjectResult.Description
+	speed, version, plan := parseVpxDescription(*getObjectResult.Description)
+	if speed > 0 {
+		d.Set("speed", speed)
+	}
+	if version != "" {
+		d.Set("version", version)
+	}
+	if plan != "" {
+		d.Set("plan", plan)
+	}
+
+	return readVpxHaPartner(d, sess, id)
+}
+
+// getVpxIpCount returns the number of IPs of a VPX, which are the IPs of the subnet it was ordered with and
+// of the subnets added by updates. Those are routed to its primary IP address, unlike other subnets.
+func getVpxIpCount(subnets []datatypes.Network_Subnet, primaryIpAddress string) int {
+	if len(subnets) == 0 {
+		return 0
+	}
+
+	count := len(subnets[0].IpAddresses)
+	for _, subnet := range subnets[1:] {
+		if subnet.EndPointIpAddress != nil && primaryIpAddress != "" &&
+			sl.Get(subnet.EndPointIpAddress.IpAddress, "").(string) == primaryIpAddress {
+			count += len(subnet.IpAddresses)
+		}
+	}
+
+	return count
+}
+
+// readVpxHaPartner reads the partner of a HA pair, and its state as reported by the NITRO API of the VPX.
+// The NITRO API is only reachable through the private network, so a failure to reach it isn't an error.
+func readVpxHaPartner(d *schema.ResourceData, sess *session.Session, id int) error {
+	var partner *datatypes.Network_Application_Delivery_Controller
+	discovered := false
+	if partnerId := d.Get("ha_partner_id").(int); partnerId != 0 {
+		result, err := services.GetNetworkApplicationDeliveryControllerService(sess).
+			Id(partnerId).
+			Mask("id,name,managementIpAddress").
+			GetObject()
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			// ha_enabled is kept, as a change of it would replace the remaining VPX
+			log.Printf("[WARN] HA partner %d of Netscaler VPX ID %d no longer exists", partnerId, id)
+			d.Set("ha_status", vpxHaStatusPartnerMissing)
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("Error retrieving HA partner of network application delivery controller: %s", err)
+		}
+		partner = &result
+	} else {
+		// The partner is only in the state of the resource which created the pair, so it is discovered
+		// after an import or with a state written before HA pairs were supported
+		var err error
+		partner, err = findVpxHaPartner(sess, id)
+		if err != nil {
+			return fmt.Errorf("Error looking up HA partner of network application delivery controller: %s", err)
+		}
+		discovered = true
+	}
+
+	var haNodes []nitroHaNode
+	if partner != nil {
+		var err error
+		haNodes, err = getVpxHaNodes(sess, id)
+		if err != nil {
+			log.Printf("[WARN] Unable to read the HA nodes of Netscaler VPX ID %d: %s", id, err)
+		} else if discovered && findNitroHaNode(haNodes, sl.Get(partner.ManagementIpAddress, "").(string)) == nil {
+			partner = nil
+		}
+	}
+
+	if partner == nil {
+		d.Set("ha_partner_id", 0)
+		d.Set("ha_partner_name", "")
+		d.Set("ha_status", "")
+		d.Set("ha_enabled", false)
+		return nil
+	}
+
+	d.Set("ha_partner_id", *partner.Id)
+	d.Set("ha_partner_name", sl.Get(partner.Name, ""))
+	d.Set("ha_enabled", true)
+
+	haStatus := "unknown"
+	if node := findNitroHaNode(haNodes, sl.Get(partner.ManagementIpAddress, "").(string)); node != nil {
+		haStatus = strings.ToLower(node.State)
+	}
+
+	d.Set("ha_status", haStatus)
+
+	return nil
+}
+
+// getVpxHaNodes returns the HA nodes of a VPX. It waits for the NITRO API for a shorter time than changes
+// do, as it runs on every refresh and an unreachable VPX is expected outside of the private network.
+func getVpxHaNodes(sess *session.Session, id int) ([]nitroHaNode, error) {
+	client, err := getVpxNitroClient(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	client.httpClient.Timeout = vpxHaStatusTimeout
+
+	return client.getHaNodes()
+}
+
+// getVpxNodeIds returns the ID of a VPX and the ID of its HA partner, unless the partner no longer exists
+func getVpxNodeIds(d *schema.ResourceData, id int) []int {
+	ids := []int{id}
+	if partnerId := d.Get("ha_partner_id").(int); partnerId != 0 &&
+		d.Get("ha_status").(string) != vpxHaStatusPartnerMissing {
+		ids = append(ids, partnerId)
+	}
+	return ids
+}
+
+// findVpxHaPartner returns the other VPX of the order of a VPX, as both nodes of a HA pair are ordered
+// together, or nil if the VPX was ordered alone.
+func findVpxHaPartner(sess *session.Session, id int) (*datatypes.Network_Application_Delivery_Controller, error) {
+	nadc, err := services.GetNetworkApplicationDeliveryControllerService(sess).
+		Id(id).
+		Mask("id,billingItem[orderItem[order[id]]]").
+		GetObject()
+	if err != nil {
+		return nil, err
+	}
+
+	if nadc.BillingItem == nil || nadc.BillingItem.OrderItem == nil || nadc.BillingItem.OrderItem.Order == nil ||
+		nadc.BillingItem.OrderItem.Order.Id == nil {
+		return nil, nil
+	}
+
+	vpxs, err := services.GetAccountService(sess).
+		Mask("id,name,managementIpAddress").
+		Filter(
+			filter.Build(
+				filter.Path("applicationDeliveryControllers.billingItem.orderItem.order.id").
+					Eq(*nadc.BillingItem.OrderItem.Order.Id),
+			),
+		).GetApplicationDeliveryControllers()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vpxs) != 2 {
+		return nil, nil
+	}
+
+	for i := range vpxs {
+		if vpxs[i].Id != nil && *vpxs[i].Id != id {
+			return &vpxs[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findNitroHaNode returns the HA node with the given IP address, or nil
+func findNitroHaNode(haNodes []nitroHaNode, ipAddress string) *nitroHaNode {
+	for i := range haNodes {
+		if ipAddress != "" && haNodes[i].IpAddress == ipAddress {
+			return &haNodes[i]
+		}
+	}
+	return nil
+}
+
+// parseVpxDescription returns the speed, version and plan of a VPX from its description, such as
+// "Citrix NetScaler VPX 10.1 10Mbps Standard"
+func parseVpxDescription(description string) (int, string, string) {
 	r, _ := regexp.Compile(" [0-9]+Mbps")
 	speedStr := r.FindString(description)
 	r, _ = regexp.Compile("[0-9]+")
 	speed, err := strconv.Atoi(r.FindString(speedStr))
-	if err == nil && speed > 0 {
-		d.Set("speed", speed)
+	if err != nil {
+		speed = 0
 	}
 
 	r, _ = regexp.Compile(" VPX [0-9]+\\.[0-9]+ ")
 	versionStr := r.FindString(description)
 	r, _ = regexp.Compile("[0-9]+\\.[0-9]+")
 	version := r.FindString(versionStr)
-	if version != "" {
-		d.Set("version", version)
-	}
 
 	r, _ = regexp.Compile(" [A-Za-z]+$")
 	planStr := r.FindString(description)
 	r, _ = regexp.Compile("[A-Za-z]+$")
 	plan := r.FindString(planStr)
-	if plan != "" {
-		d.Set("plan", plan)
+
+	return speed, version, plan
+}
+
+func resourceSoftLayerLbVpxUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
 	}
 
-	return nil
+	// Both nodes of a HA pair are kept at the same speed, plan and number of IPs
+	ids := getVpxNodeIds(d, id)
+
+	if d.HasChange("speed") || d.HasChange("plan") {
+		speed := d.Get("speed").(int)
+		plan := d.Get("plan").(string)
+
+		price, err := findVPXUpgradePriceItem(d.Get("version").(string), speed, plan, meta)
+		if err != nil {
+			return fmt.Errorf("Error Cannot find Application Delivery Controller prices '%s'.", err)
+		}
+
+		for _, nadcId := range ids {
+			err = upgradeVpx(sess, nadcId, price, speed, plan)
+			if err != nil {
+				return fmt.Errorf("Error upgrading network application delivery controller: %s", err)
+			}
+		}
+	}
+
+	if d.HasChange("ip_count") {
+		o, n := d.GetChange("ip_count")
+		added := n.(int) - o.(int)
+		if added < 0 {
+			return fmt.Errorf("Error updating network application delivery controller: IPs cannot be removed from a VPX")
+		}
+
+		for _, nadcId := range ids {
+			err = addVpxIps(sess, nadcId, added)
+			if err != nil {
+				return fmt.Errorf("Error adding IPs to network application delivery controller: %s", err)
+			}
+		}
+	}
+
+	return resourceSoftLayerLbVpxRead(d, meta)
+}
+
+// upgradeVpx places an upgrade order for the speed and plan of a VPX, and waits until the VPX reports them
+func upgradeVpx(sess *session.Session, id int, price datatypes.Product_Item_Price, speed int, plan string) error {
+	service := services.GetNetworkApplicationDeliveryControllerService(sess)
+
+	nadc, err := service.Id(id).Mask("id,datacenter[id]").GetObject()
+	if err != nil {
+		return err
+	}
+
+	opts := datatypes.Container_Product_Order_Network_Application_Delivery_Controller{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: sl.Int(PACKAGE_ID_APPLICATION_DELIVERY_CONTROLLER),
+			Prices:    []datatypes.Product_Item_Price{price},
+			Quantity:  sl.Int(1),
+		},
+		ApplicationDeliveryControllerId: sl.Int(id),
+	}
+	if nadc.Datacenter != nil && nadc.Datacenter.Id != nil {
+		opts.Location = sl.String(strconv.Itoa(*nadc.Datacenter.Id))
+	}
+
+	log.Printf("[INFO] Upgrading Netscaler VPX ID %d to %dMbps %s", id, speed, plan)
+
+	_, err = services.GetProductOrderService(sess).PlaceOrder(&opts, sl.Bool(false))
+	if err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			nadc, err := service.Id(id).Mask("id,description").GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			currentSpeed, _, currentPlan := parseVpxDescription(sl.Get(nadc.Description, "").(string))
+			if currentSpeed == speed && strings.ToLower(currentPlan) == strings.ToLower(plan) {
+				return nadc, "complete", nil
+			}
+			return nadc, "pending", nil
+		},
+		Timeout:    30 * time.Minute,
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return err
+	}
+
+	return waitForVpxReady(id, sess)
+}
+
+// addVpxIps orders a static subnet of count IPs routed to the primary IP address of a VPX
+func addVpxIps(sess *session.Session, id int, count int) error {
+	nadc, err := services.GetNetworkApplicationDeliveryControllerService(sess).
+		Id(id).
+		Mask("id,primaryIpAddress").
+		GetObject()
+	if err != nil {
+		return err
+	}
+
+	if nadc.PrimaryIpAddress == nil {
+		return fmt.Errorf("No primary IP address for Netscaler VPX ID: %d", id)
+	}
+
+	ipAddress, err := services.GetNetworkSubnetIpAddressService(sess).GetByIpAddress(nadc.PrimaryIpAddress)
+	if err != nil {
+		return fmt.Errorf("Error looking up primary ip %s: %s", *nadc.PrimaryIpAddress, err)
+	}
+	if ipAddress.Id == nil {
+		return fmt.Errorf("Unable to locate the primary ip %s", *nadc.PrimaryIpAddress)
+	}
+
+	pkg, err := product.GetPackageByType(sess, AdditionalServicesPackageType)
+	if err != nil {
+		return err
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return err
+	}
+
+	keyName := getSubnetItemKeyName("Static", false, 4, count)
+
+	var subnetItem *datatypes.Product_Item
+	for i, item := range productItems {
+		if item.KeyName != nil && *item.KeyName == keyName && len(item.Prices) > 0 {
+			subnetItem = &productItems[i]
+			break
+		}
+	}
+
+	if subnetItem == nil {
+		return fmt.Errorf("No product items matching %s could be found, IPs can only be added in blocks of 1, 2, 4, 8, 16 or 32", keyName)
+	}
+
+	log.Printf("[INFO] Adding %d IPs to Netscaler VPX ID %d", count, id)
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(&datatypes.Container_Product_Order_Network_Subnet{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: subnetItem.Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+		EndPointIpAddressId: ipAddress.Id,
+	}, sl.Bool(false))
+	if err != nil {
+		return err
+	}
+
+	_, err = findSubnetByOrderId(sess, *receipt.OrderId)
+	return err
 }
 
 func resourceSoftLayerLbVpxDelete(d *schema.ResourceData, meta interface{}) error {
@@ -553,20 +1026,20 @@ func resourceSoftLayerLbVpxDelete(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
 	}
 
-	billingItem, err := service.Id(id).GetBillingItem()
-	if err != nil {
-		return fmt.Errorf("Error deleting network application delivery controller: %s", err)
-	}
+	ids := getVpxNodeIds(d, id)
 
-	if *billingItem.Id > 0 {
-		billingItemService := services.GetBillingItemService(sess)
-		deleted, err := billingItemService.Id(*billingItem.Id).CancelService()
+	for _, nadcId := range ids {
+		billingItem, err := service.Id(nadcId).GetBillingItem()
 		if err != nil {
 			return fmt.Errorf("Error deleting network application delivery controller: %s", err)
 		}
 
-		if deleted {
-			return nil
+		if billingItem.Id != nil && *billingItem.Id > 0 {
+			billingItemService := services.GetBillingItemService(sess)
+			_, err := billingItemService.Id(*billingItem.Id).CancelService()
+			if err != nil {
+				return fmt.Errorf("Error deleting network application delivery controller: %s", err)
+			}
 		}
 	}
 
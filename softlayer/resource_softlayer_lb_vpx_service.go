@@ -19,12 +19,14 @@ import (
 
 func resourceSoftLayerLbVpxService() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceSoftLayerLbVpxServiceCreate,
-		Read:     resourceSoftLayerLbVpxServiceRead,
-		Update:   resourceSoftLayerLbVpxServiceUpdate,
-		Delete:   resourceSoftLayerLbVpxServiceDelete,
-		Exists:   resourceSoftLayerLbVpxServiceExists,
-		Importer: &schema.ResourceImporter{},
+		Create: resourceSoftLayerLbVpxServiceCreate,
+		Read:   resourceSoftLayerLbVpxServiceRead,
+		Update: resourceSoftLayerLbVpxServiceUpdate,
+		Delete: resourceSoftLayerLbVpxServiceDelete,
+		Exists: resourceSoftLayerLbVpxServiceExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSoftLayerLbVpxServiceImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -71,6 +73,53 @@ func resourceSoftLayerLbVpxService() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			"management_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "softlayer",
+				ValidateFunc:     validateLbVpxManagementMode,
+				DiffSuppressFunc: suppressLbVpxManagementModeDiff,
+			},
+
+			"health_monitor": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"http_request": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"response_codes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"receive_string": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interval": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  5,
+						},
+						"response_timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  2,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -112,6 +161,14 @@ func updateVpxService(sess *session.Session, nadcId int, lbVip *datatypes.Networ
 }
 
 func resourceSoftLayerLbVpxServiceCreate(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxServiceNitroCreate(d, meta)
+	}
+
+	if _, ok := d.GetOk("health_monitor"); ok {
+		return fmt.Errorf("health_monitor requires management_mode = \"nitro\"")
+	}
+
 	sess := meta.(*session.Session)
 
 	vipId := d.Get("vip_id").(string)
@@ -165,6 +222,10 @@ func resourceSoftLayerLbVpxServiceCreate(d *schema.ResourceData, meta interface{
 }
 
 func resourceSoftLayerLbVpxServiceRead(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxServiceNitroRead(d, meta)
+	}
+
 	sess := meta.(*session.Session)
 
 	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
@@ -184,11 +245,24 @@ func resourceSoftLayerLbVpxServiceRead(d *schema.ResourceData, meta interface{})
 	d.Set("weight", *lbService.Weight)
 	d.Set("health_check", *lbService.HealthCheck)
 	d.Set("connection_limit", *lbService.ConnectionLimit)
+	d.Set("management_mode", "softlayer")
 
 	return nil
 }
 
+func resourceSoftLayerLbVpxServiceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importLbVpxManagementMode(d, 3)
+}
+
 func resourceSoftLayerLbVpxServiceUpdate(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxServiceNitroUpdate(d, meta)
+	}
+
+	if _, ok := d.GetOk("health_monitor"); ok {
+		return fmt.Errorf("health_monitor requires management_mode = \"nitro\"")
+	}
+
 	sess := meta.(*session.Session)
 
 	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
@@ -243,6 +317,10 @@ func resourceSoftLayerLbVpxServiceUpdate(d *schema.ResourceData, meta interface{
 }
 
 func resourceSoftLayerLbVpxServiceDelete(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxServiceNitroDelete(d, meta)
+	}
+
 	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
 	if err != nil {
 		return fmt.Errorf("Error parsing vip id: %s", err)
@@ -288,6 +366,10 @@ func resourceSoftLayerLbVpxServiceDelete(d *schema.ResourceData, meta interface{
 }
 
 func resourceSoftLayerLbVpxServiceExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxServiceNitroExists(d, meta)
+	}
+
 	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
 	if err != nil {
 		return false, fmt.Errorf("Error parsing vip id: %s", err)
@@ -301,3 +383,207 @@ func resourceSoftLayerLbVpxServiceExists(d *schema.ResourceData, meta interface{
 
 	return err == nil && *lbService.Name == serviceName, nil
 }
+
+func resourceSoftLayerLbVpxServiceNitroCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vipId := d.Get("vip_id").(string)
+	vipName, nadcId, _, err := parseServiceId(vipId)
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+	serviceName := d.Get("name").(string)
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Error creating LoadBalancer Service: %s", err)
+	}
+
+	vip, err := readNitroVip(client, vipName)
+	if err != nil {
+		return fmt.Errorf("Error creating LoadBalancer Service: %s", err)
+	}
+
+	if vip.vserverType() != "lbvserver" {
+		return fmt.Errorf("Error creating LoadBalancer Service: VIP %s has content switching rules, "+
+			"services must be added to the VIPs it switches to", vipName)
+	}
+
+	// The VIP terminates SSL, so its services use the protocol of the VIP without SSL
+	err = createNitroService(client, expandNitroLbService(d, vipName, lbVpxVipType(vip.Vserver.ServiceType)))
+	if err != nil {
+		return fmt.Errorf("Error creating LoadBalancer Service: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", vipId, serviceName))
+
+	return resourceSoftLayerLbVpxServiceNitroRead(d, meta)
+}
+
+func resourceSoftLayerLbVpxServiceNitroRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Unable to get load balancer service %s: %s", serviceName, err)
+	}
+
+	lbService, err := readNitroService(client, vipName, serviceName)
+	if err != nil {
+		return fmt.Errorf("Unable to get load balancer service %s: %s", serviceName, err)
+	}
+
+	d.Set("vip_id", strconv.Itoa(nadcId)+":"+vipName)
+	d.Set("name", lbService.Service.Name)
+	d.Set("destination_ip_address", lbService.Service.IpAddress)
+	d.Set("destination_port", int(lbService.Service.Port))
+	d.Set("weight", lbService.Weight)
+	d.Set("connection_limit", int(lbService.Service.MaxClient))
+
+	monitor := lbService.Monitor
+	if monitor.Type == "" {
+		if monitor.MonitorName != "" && monitor.MonitorName != nitroBuiltinMonitor(d.Get("health_check").(string)) {
+			d.Set("health_check", lbVpxHealthCheck(monitor.MonitorName))
+		}
+		d.Set("health_monitor", []map[string]interface{}{})
+	} else {
+		responseCodes := make([]interface{}, 0, len(monitor.RespCode))
+		for _, responseCode := range monitor.RespCode {
+			responseCodes = append(responseCodes, responseCode)
+		}
+
+		d.Set("health_monitor", []map[string]interface{}{
+			{
+				"type":             monitor.Type,
+				"http_request":     monitor.HttpRequest,
+				"response_codes":   responseCodes,
+				"receive_string":   monitor.Recv,
+				"interval":         int(monitor.Interval),
+				"response_timeout": int(monitor.RespTimeout),
+			},
+		})
+	}
+
+	return nil
+}
+
+func resourceSoftLayerLbVpxServiceNitroUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Error updating LoadBalancer Service: %s", err)
+	}
+
+	old, err := readNitroService(client, vipName, serviceName)
+	if err != nil {
+		return fmt.Errorf("Unable to get load balancer service: %s", err)
+	}
+
+	lbService := expandNitroLbService(d, vipName, old.Service.ServiceType)
+	if !d.HasChange("health_check") && !d.HasChange("health_monitor") {
+		old.Monitor = lbService.Monitor
+	}
+
+	err = updateNitroService(client, old, lbService)
+	if err != nil {
+		return fmt.Errorf("Error updating LoadBalancer Service: %s", err)
+	}
+
+	return resourceSoftLayerLbVpxServiceNitroRead(d, meta)
+}
+
+func resourceSoftLayerLbVpxServiceNitroDelete(d *schema.ResourceData, meta interface{}) error {
+	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	client, err := getVpxNitroClient(meta.(*session.Session), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error deleting LoadBalancer Service %s: %s", serviceName, err)
+	}
+
+	lbService, err := readNitroService(client, vipName, serviceName)
+	if err != nil {
+		if isNitroNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting LoadBalancer Service %s: %s", serviceName, err)
+	}
+
+	err = deleteNitroService(client, lbService)
+	if err != nil {
+		return fmt.Errorf("Error deleting LoadBalancer Service %s: %s", serviceName, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerLbVpxServiceNitroExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	vipName, nadcId, serviceName, err := parseServiceId(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	client, err := getVpxNitroClient(meta.(*session.Session), nadcId)
+	if err != nil {
+		return false, fmt.Errorf("Unable to get load balancer service %s: %s", serviceName, err)
+	}
+
+	lbService, err := readNitroService(client, vipName, serviceName)
+	if err != nil {
+		if isNitroNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Unable to get load balancer service %s: %s", serviceName, err)
+	}
+
+	return lbService.Service.Name == serviceName, nil
+}
+
+// expandNitroLbService builds the NITRO configuration of a service. Its health check is a built-in monitor
+// of the VPX, unless it has a health_monitor.
+func expandNitroLbService(d *schema.ResourceData, vipName, serviceType string) nitroLbService {
+	serviceName := d.Get("name").(string)
+
+	lbService := nitroLbService{
+		Service: nitroService{
+			Name:        serviceName,
+			Ip:          d.Get("destination_ip_address").(string),
+			Port:        nitroInt(d.Get("destination_port").(int)),
+			ServiceType: serviceType,
+			MaxClient:   nitroInt(d.Get("connection_limit").(int)),
+		},
+		VipName: vipName,
+		Weight:  d.Get("weight").(int),
+		Monitor: nitroMonitor{
+			MonitorName: nitroBuiltinMonitor(d.Get("health_check").(string)),
+		},
+	}
+
+	if monitors := d.Get("health_monitor").([]interface{}); len(monitors) > 0 {
+		monitor := monitors[0].(map[string]interface{})
+		lbService.Monitor = nitroMonitor{
+			MonitorName: nitroServiceMonitorName(serviceName),
+			Type:        strings.ToUpper(monitor["type"].(string)),
+			HttpRequest: monitor["http_request"].(string),
+			RespCode:    expandStringList(monitor["response_codes"].(*schema.Set).List()),
+			Recv:        monitor["receive_string"].(string),
+			Interval:    nitroInt(monitor["interval"].(int)),
+			RespTimeout: nitroInt(monitor["response_timeout"].(int)),
+		}
+	}
+
+	return lbService
+}
@@ -32,6 +32,28 @@ func TestAccSoftLayerLbVpxService_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbVpxService_Nitro(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerLbVpxServiceConfig_nitro,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_nitro_service", "weight", "40"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_nitro_service", "connection_limit", "500"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_nitro_service", "health_monitor.0.type", "HTTP-ECV"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_nitro_service", "health_monitor.0.receive_string", "OK"),
+				),
+			},
+		},
+	})
+}
+
 var testAccCheckSoftLayerLbVpxServiceConfig_basic = `
 
 resource "softlayer_virtual_guest" "vm1" {
@@ -99,3 +121,41 @@ resource "softlayer_lb_vpx_service" "testacc_service2" {
   health_check = "HTTP"
 }
 `
+
+var testAccCheckSoftLayerLbVpxServiceConfig_nitro = `
+resource "softlayer_lb_vpx" "testacc_nitro_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_nitro_vip" {
+    name = "test_nitro_vip"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_nitro_nadc.id}"
+    management_mode = "nitro"
+    load_balancing_method = "lc"
+    source_port = 80
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_nitro_nadc.vip_pool[0]}"
+}
+
+resource "softlayer_lb_vpx_service" "testacc_nitro_service" {
+    name = "test_nitro_service"
+    vip_id = "${softlayer_lb_vpx_vip.testacc_nitro_vip.id}"
+    management_mode = "nitro"
+    destination_ip_address = "10.0.0.5"
+    destination_port = 8080
+    weight = 40
+    connection_limit = 500
+    health_check = "HTTP"
+
+    health_monitor {
+        type = "HTTP-ECV"
+        http_request = "GET /health"
+        receive_string = "OK"
+        interval = 10
+    }
+}
+`
@@ -2,6 +2,7 @@ package softlayer
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/services"
 	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
 )
 
 func TestAccSoftLayerLbVpx_Basic(t *testing.T) {
@@ -93,3 +95,111 @@ resource "softlayer_lb_vpx" "testacc_foobar_vpx" {
     public_subnet = "184.172.106.152/29"
     private_subnet = "10.146.95.64/26"
 }`
+
+func TestAccSoftLayerLbVpx_HaUpgrade(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerLbVpxConfig_ha, 10, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "ha_enabled", "true"),
+					resource.TestMatchResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "ha_partner_id", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestMatchResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "ha_partner_name", regexp.MustCompile(".+")),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "speed", "10"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "ip_count", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerLbVpxConfig_ha, 200, 4),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "speed", "200"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "ip_count", "4"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "vip_pool.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseVpxDescription(t *testing.T) {
+	speed, version, plan := parseVpxDescription("Citrix NetScaler VPX 10.1 200Mbps Platinum")
+	if speed != 200 || version != "10.1" || plan != "Platinum" {
+		t.Errorf("Unexpected speed %d, version %s or plan %s", speed, version, plan)
+	}
+
+	speed, version, plan = parseVpxDescription("")
+	if speed != 0 || version != "" || plan != "" {
+		t.Errorf("Expected no speed, version or plan, got %d, %s and %s", speed, version, plan)
+	}
+}
+
+func TestGetVpxIpCount(t *testing.T) {
+	subnet := func(endPoint string, ips ...string) datatypes.Network_Subnet {
+		subnet := datatypes.Network_Subnet{}
+		if endPoint != "" {
+			subnet.EndPointIpAddress = &datatypes.Network_Subnet_IpAddress{IpAddress: sl.String(endPoint)}
+		}
+		for _, ip := range ips {
+			subnet.IpAddresses = append(subnet.IpAddresses, datatypes.Network_Subnet_IpAddress{IpAddress: sl.String(ip)})
+		}
+		return subnet
+	}
+
+	if count := getVpxIpCount(nil, "10.0.0.1"); count != 0 {
+		t.Errorf("Expected no IPs, got %d", count)
+	}
+
+	subnets := []datatypes.Network_Subnet{
+		subnet("", "192.0.2.1", "192.0.2.2"),
+		subnet("10.0.0.1", "198.51.100.1", "198.51.100.2", "198.51.100.3", "198.51.100.4"),
+		subnet("10.0.0.2", "203.0.113.1"),
+	}
+
+	// Only the subnets routed to the primary IP address were added by updates
+	if count := getVpxIpCount(subnets, "10.0.0.1"); count != 6 {
+		t.Errorf("Expected 6 IPs, got %d", count)
+	}
+
+	if count := getVpxIpCount(subnets, ""); count != 2 {
+		t.Errorf("Expected 2 IPs, got %d", count)
+	}
+}
+
+func TestFindNitroHaNode(t *testing.T) {
+	haNodes := []nitroHaNode{
+		{Id: "0", IpAddress: "10.0.0.1", State: "Primary"},
+		{Id: "1", IpAddress: "10.0.0.2", State: "Secondary"},
+	}
+
+	if node := findNitroHaNode(haNodes, "10.0.0.2"); node == nil || node.State != "Secondary" {
+		t.Errorf("Expected the secondary node, got %v", node)
+	}
+
+	if node := findNitroHaNode(haNodes, "10.0.0.3"); node != nil {
+		t.Errorf("Expected no node, got %v", node)
+	}
+
+	if node := findNitroHaNode(haNodes, ""); node != nil {
+		t.Errorf("Expected no node, got %v", node)
+	}
+}
+
+const testAccCheckSoftLayerLbVpxConfig_ha = `
+resource "softlayer_lb_vpx" "testacc_ha_vpx" {
+    datacenter = "dal06"
+    speed = %d
+    version = "10.1"
+    plan = "Standard"
+    ip_count = %d
+    ha_enabled = true
+}`
@@ -18,12 +18,14 @@ import (
 
 func resourceSoftLayerLbVpxVip() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceSoftLayerLbVpxVipCreate,
-		Read:     resourceSoftLayerLbVpxVipRead,
-		Update:   resourceSoftLayerLbVpxVipUpdate,
-		Delete:   resourceSoftLayerLbVpxVipDelete,
-		Exists:   resourceSoftLayerLbVpxVipExists,
-		Importer: &schema.ResourceImporter{},
+		Create: resourceSoftLayerLbVpxVipCreate,
+		Read:   resourceSoftLayerLbVpxVipRead,
+		Update: resourceSoftLayerLbVpxVipUpdate,
+		Delete: resourceSoftLayerLbVpxVipDelete,
+		Exists: resourceSoftLayerLbVpxVipExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSoftLayerLbVpxVipImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"nad_controller_id": {
@@ -61,6 +63,56 @@ func resourceSoftLayerLbVpxVip() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			"management_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "softlayer",
+				ValidateFunc:     validateLbVpxManagementMode,
+				DiffSuppressFunc: suppressLbVpxManagementModeDiff,
+			},
+
+			"persistence": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"persistence_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"security_certificate_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"content_switching_rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"expression": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"target_vip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -85,6 +137,14 @@ func parseId(id string) (int, string, error) {
 }
 
 func resourceSoftLayerLbVpxVipCreate(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxVipNitroCreate(d, meta)
+	}
+
+	if err := checkLbVpxVipSoftLayerMode(d); err != nil {
+		return err
+	}
+
 	sess := meta.(*session.Session)
 	service := services.GetNetworkApplicationDeliveryControllerService(sess)
 
@@ -140,6 +200,10 @@ func resourceSoftLayerLbVpxVipCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceSoftLayerLbVpxVipRead(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxVipNitroRead(d, meta)
+	}
+
 	sess := meta.(*session.Session)
 
 	nadcId, vipName, err := parseId(d.Id())
@@ -173,10 +237,20 @@ func resourceSoftLayerLbVpxVipRead(d *schema.ResourceData, meta interface{}) err
 		d.Set("virtual_ip_address", *vip.VirtualIpAddress)
 	}
 
+	d.Set("management_mode", "softlayer")
+
 	return nil
 }
 
 func resourceSoftLayerLbVpxVipUpdate(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxVipNitroUpdate(d, meta)
+	}
+
+	if err := checkLbVpxVipSoftLayerMode(d); err != nil {
+		return err
+	}
+
 	sess := meta.(*session.Session)
 	service := services.GetNetworkApplicationDeliveryControllerService(sess)
 
@@ -217,6 +291,10 @@ func resourceSoftLayerLbVpxVipUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceSoftLayerLbVpxVipDelete(d *schema.ResourceData, meta interface{}) error {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxVipNitroDelete(d, meta)
+	}
+
 	sess := meta.(*session.Session)
 	service := services.GetNetworkApplicationDeliveryControllerService(sess)
 
@@ -257,6 +335,10 @@ func resourceSoftLayerLbVpxVipDelete(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceSoftLayerLbVpxVipExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	if isLbVpxNitroMode(d) {
+		return resourceSoftLayerLbVpxVipNitroExists(d, meta)
+	}
+
 	sess := meta.(*session.Session)
 
 	nadcId, vipName, err := parseId(d.Id())
@@ -268,3 +350,288 @@ func resourceSoftLayerLbVpxVipExists(d *schema.ResourceData, meta interface{}) (
 
 	return vip != nil && err == nil && *vip.Name == vipName, nil
 }
+
+func validateLbVpxManagementMode(v interface{}, k string) (ws []string, errs []error) {
+	mode := v.(string)
+	if mode != "softlayer" && mode != "nitro" {
+		errs = append(errs, fmt.Errorf("%q must be either 'softlayer' or 'nitro': %s", k, mode))
+	}
+	return
+}
+
+// suppressLbVpxManagementModeDiff ignores a management mode missing from the state of an existing VIP or
+// service. Those states were written before the NITRO mode existed, when the SoftLayer mode was the only one.
+func suppressLbVpxManagementModeDiff(k, o, n string, d *schema.ResourceData) bool {
+	return d.Id() != "" && o == "" && n == "softlayer"
+}
+
+// importLbVpxManagementMode sets the management mode of an imported VIP or service. The mode can follow
+// the ID of the VIP or service, which has idParts parts, such as "123:web:nitro" for a VIP. It is the
+// SoftLayer mode otherwise.
+func importLbVpxManagementMode(d *schema.ResourceData, idParts int) ([]*schema.ResourceData, error) {
+	mode := "softlayer"
+
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) == idParts+1 {
+		mode = parts[idParts]
+		if _, errs := validateLbVpxManagementMode(mode, "management_mode"); len(errs) > 0 {
+			return nil, errs[0]
+		}
+		d.SetId(strings.Join(parts[:idParts], ":"))
+	}
+
+	d.Set("management_mode", mode)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSoftLayerLbVpxVipImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return importLbVpxManagementMode(d, 2)
+}
+
+// isLbVpxNitroMode returns whether a VIP or service is managed through the NITRO API of its VPX
+func isLbVpxNitroMode(d *schema.ResourceData) bool {
+	return d.Get("management_mode").(string) == "nitro"
+}
+
+func checkLbVpxVipSoftLayerMode(d *schema.ResourceData) error {
+	for _, key := range []string{"persistence", "security_certificate_id", "content_switching_rule"} {
+		if _, ok := d.GetOk(key); ok {
+			return fmt.Errorf("%s requires management_mode = \"nitro\"", key)
+		}
+	}
+	return nil
+}
+
+func resourceSoftLayerLbVpxVipNitroCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	nadcId := d.Get("nad_controller_id").(int)
+	vipName := d.Get("name").(string)
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Error creating Virtual Ip Address: %s", err)
+	}
+
+	vip, err := expandNitroVip(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating Virtual Ip Address: %s", err)
+	}
+
+	err = createNitroVip(client, vip)
+	if err != nil {
+		return fmt.Errorf("Error creating Virtual Ip Address: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", nadcId, vipName))
+
+	log.Printf("[INFO] Netscaler VPX VIP ID: %s", d.Id())
+
+	return resourceSoftLayerLbVpxVipNitroRead(d, meta)
+}
+
+func resourceSoftLayerLbVpxVipNitroRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	nadcId, vipName, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("softlayer_lb_vpx : %s", err)
+	}
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("softlayer_lb_vpx : %s", err)
+	}
+
+	vip, err := readNitroVip(client, vipName)
+	if err != nil {
+		return fmt.Errorf("softlayer_lb_vpx : while looking up a virtual ip address : %s", err)
+	}
+
+	d.Set("nad_controller_id", nadcId)
+	d.Set("name", vip.Vserver.Name)
+	d.Set("source_port", int(vip.Vserver.Port))
+	d.Set("type", lbVpxVipType(vip.Vserver.ServiceType))
+	d.Set("virtual_ip_address", vip.Vserver.IPv46)
+
+	// Content switching virtual servers have no load balancing method or persistence
+	if vip.vserverType() == "lbvserver" {
+		if nitroLbMethod(d.Get("load_balancing_method").(string)) != vip.Vserver.LbMethod {
+			d.Set("load_balancing_method", vip.Vserver.LbMethod)
+		}
+
+		persistence := vip.Vserver.PersistenceType
+		if persistence == "NONE" {
+			persistence = ""
+		}
+		if persistence != strings.ToUpper(d.Get("persistence").(string)) {
+			d.Set("persistence", persistence)
+		}
+		d.Set("persistence_timeout", int(vip.Vserver.Timeout))
+	}
+
+	certificateId := 0
+	if vip.CertKey != nil {
+		certificateId = parseNitroCertKeyName(vip.CertKey.Name)
+	}
+	d.Set("security_certificate_id", certificateId)
+
+	rules := make([]map[string]interface{}, 0, len(vip.Rules))
+	for _, rule := range vip.Rules {
+		rules = append(rules, map[string]interface{}{
+			"name":       rule.Name,
+			"expression": rule.Expression,
+			"target_vip": rule.Target,
+			"priority":   rule.Priority,
+		})
+	}
+	d.Set("content_switching_rule", rules)
+
+	return nil
+}
+
+func resourceSoftLayerLbVpxVipNitroUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	nadcId, vipName, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("softlayer_lb_vpx : %s", err)
+	}
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Error updating Virtual Ip Address: %s", err)
+	}
+
+	old, err := readNitroVip(client, vipName)
+	if err != nil {
+		return fmt.Errorf("Error updating Virtual Ip Address: %s", err)
+	}
+
+	vip, err := expandNitroVip(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error updating Virtual Ip Address: %s", err)
+	}
+
+	if !d.HasChange("content_switching_rule") {
+		old.Rules = vip.Rules
+	}
+
+	err = updateNitroVip(client, old, vip)
+	if err != nil {
+		return fmt.Errorf("Error updating Virtual Ip Address: %s", err)
+	}
+
+	return resourceSoftLayerLbVpxVipNitroRead(d, meta)
+}
+
+func resourceSoftLayerLbVpxVipNitroDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	nadcId, vipName, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("softlayer_lb_vpx : %s", err)
+	}
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Error deleting Virtual Ip Address %s: %s", vipName, err)
+	}
+
+	vip, err := readNitroVip(client, vipName)
+	if err != nil {
+		if isNitroNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Virtual Ip Address %s: %s", vipName, err)
+	}
+
+	err = deleteNitroVip(client, vip)
+	if err != nil {
+		return fmt.Errorf("Error deleting Virtual Ip Address %s: %s", vipName, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerLbVpxVipNitroExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	nadcId, vipName, err := parseId(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("softlayer_lb_vpx : %s", err)
+	}
+
+	client, err := getVpxNitroClient(sess, nadcId)
+	if err != nil {
+		return false, fmt.Errorf("softlayer_lb_vpx : %s", err)
+	}
+
+	vip, err := readNitroVip(client, vipName)
+	if err != nil {
+		if isNitroNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("softlayer_lb_vpx : while looking up a virtual ip address : %s", err)
+	}
+
+	return vip.Vserver.Name == vipName, nil
+}
+
+// expandNitroVip builds the NITRO configuration of a VIP. The certificate and key for SSL offload are
+// retrieved from the SoftLayer security certificate.
+func expandNitroVip(d *schema.ResourceData, sess *session.Session) (nitroVip, error) {
+	certificateId := d.Get("security_certificate_id").(int)
+
+	serviceType, err := nitroVserverServiceType(d.Get("type").(string), certificateId != 0)
+	if err != nil {
+		return nitroVip{}, err
+	}
+
+	persistence := strings.ToUpper(d.Get("persistence").(string))
+	if persistence == "" {
+		persistence = "NONE"
+	}
+
+	vip := nitroVip{
+		Vserver: nitroVserver{
+			Name:            d.Get("name").(string),
+			ServiceType:     serviceType,
+			IPv46:           d.Get("virtual_ip_address").(string),
+			Port:            nitroInt(d.Get("source_port").(int)),
+			LbMethod:        nitroLbMethod(d.Get("load_balancing_method").(string)),
+			PersistenceType: persistence,
+			Timeout:         nitroInt(d.Get("persistence_timeout").(int)),
+		},
+	}
+
+	if certificateId != 0 {
+		cert, err := services.GetSecurityCertificateService(sess).
+			Id(certificateId).
+			Mask("id,certificate,privateKey,intermediateCertificate").
+			GetObject()
+		if err != nil {
+			return nitroVip{}, fmt.Errorf("Unable to get Security Certificate: %s", err)
+		}
+
+		vip.CertKey = &nitroCertKey{
+			Name:         nitroCertKeyName(certificateId),
+			Certificate:  sl.Get(cert.Certificate, "").(string),
+			PrivateKey:   sl.Get(cert.PrivateKey, "").(string),
+			Intermediate: sl.Get(cert.IntermediateCertificate, "").(string),
+		}
+	}
+
+	for _, r := range d.Get("content_switching_rule").([]interface{}) {
+		rule := r.(map[string]interface{})
+		vip.Rules = append(vip.Rules, nitroCsRule{
+			Name:       rule["name"].(string),
+			Expression: rule["expression"].(string),
+			Target:     rule["target_vip"].(string),
+			Priority:   rule["priority"].(int),
+		})
+	}
+
+	return vip, nil
+}
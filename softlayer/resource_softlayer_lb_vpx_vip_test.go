@@ -35,6 +35,30 @@ func TestAccSoftLayerLbVpxVip_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbVpxVip_Nitro(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerLbVpxVipConfig_nitro,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_nitro_api", "management_mode", "nitro"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_nitro_api", "persistence", "SOURCEIP"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_nitro_api", "persistence_timeout", "5"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_nitro_front", "content_switching_rule.#", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_nitro_front", "content_switching_rule.0.target_vip", "test_nitro_api"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSoftLayerLbVpxVipDestroy(s *terraform.State) error {
 	sess := testAccProvider.Meta().(*session.Session)
 
@@ -76,3 +100,42 @@ resource "softlayer_lb_vpx_vip" "testacc_vip" {
     virtual_ip_address = "${softlayer_lb_vpx.testacc_foobar_nadc.vip_pool[0]}"
 }
 `
+
+var testAccCheckSoftLayerLbVpxVipConfig_nitro = `
+resource "softlayer_lb_vpx" "testacc_nitro_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_nitro_api" {
+    name = "test_nitro_api"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_nitro_nadc.id}"
+    management_mode = "nitro"
+    load_balancing_method = "lc"
+    source_port = 8080
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_nitro_nadc.vip_pool[0]}"
+    persistence = "SOURCEIP"
+    persistence_timeout = 5
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_nitro_front" {
+    name = "test_nitro_front"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_nitro_nadc.id}"
+    management_mode = "nitro"
+    load_balancing_method = "rr"
+    source_port = 80
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_nitro_nadc.vip_pool[1]}"
+
+    content_switching_rule {
+        name = "test_nitro_api_rule"
+        expression = "HTTP.REQ.URL.STARTSWITH(\"/api\")"
+        target_vip = "${softlayer_lb_vpx_vip.testacc_nitro_api.name}"
+        priority = 10
+    }
+}
+`
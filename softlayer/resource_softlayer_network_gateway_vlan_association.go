@@ -0,0 +1,222 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerNetworkGatewayVlanAssociation() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerNetworkGatewayVlanAssociationCreate,
+		Read:     resourceSoftLayerNetworkGatewayVlanAssociationRead,
+		Update:   resourceSoftLayerNetworkGatewayVlanAssociationUpdate,
+		Delete:   resourceSoftLayerNetworkGatewayVlanAssociationDelete,
+		Exists:   resourceSoftLayerNetworkGatewayVlanAssociationExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"gateway_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"network_vlan_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"bypass": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkGatewayVlanService(sess)
+
+	gatewayId := d.Get("gateway_id").(int)
+	vlanId := d.Get("network_vlan_id").(int)
+
+	log.Printf("[INFO] Associating vlan %d with network gateway %d", vlanId, gatewayId)
+
+	association, err := service.CreateObject(&datatypes.Network_Gateway_Vlan{
+		NetworkGatewayId: sl.Int(gatewayId),
+		NetworkVlanId:    sl.Int(vlanId),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating network gateway vlan association: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *association.Id))
+
+	err = waitForNetworkGatewayTransactions(sess, gatewayId)
+	if err != nil {
+		return fmt.Errorf("Error creating network gateway vlan association: %s", err)
+	}
+
+	// A vlan is bypassed when it is associated, it is routed through the gateway once unbypassed
+	if !d.Get("bypass").(bool) {
+		err = setNetworkGatewayVlanBypass(sess, gatewayId, *association.Id, false)
+		if err != nil {
+			return fmt.Errorf("Error routing vlan %d through network gateway: %s", vlanId, err)
+		}
+	}
+
+	return resourceSoftLayerNetworkGatewayVlanAssociationRead(d, meta)
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkGatewayVlanService(sess)
+
+	associationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid network gateway vlan association ID, must be an integer: %s", err)
+	}
+
+	association, err := service.Id(associationId).Mask("id,networkGatewayId,networkVlanId,bypassFlag").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving network gateway vlan association: %s", err)
+	}
+
+	d.Set("id", *association.Id)
+	d.Set("gateway_id", sl.Get(association.NetworkGatewayId, 0))
+	d.Set("network_vlan_id", sl.Get(association.NetworkVlanId, 0))
+	d.Set("bypass", sl.Get(association.BypassFlag, false))
+
+	return nil
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	associationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid network gateway vlan association ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("bypass") {
+		err = setNetworkGatewayVlanBypass(sess, d.Get("gateway_id").(int), associationId, d.Get("bypass").(bool))
+		if err != nil {
+			return fmt.Errorf("Error updating network gateway vlan association: %s", err)
+		}
+	}
+
+	return resourceSoftLayerNetworkGatewayVlanAssociationRead(d, meta)
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkGatewayVlanService(sess)
+
+	associationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid network gateway vlan association ID, must be an integer: %s", err)
+	}
+
+	err = service.Id(associationId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting network gateway vlan association: %s", err)
+	}
+
+	err = waitForNetworkGatewayTransactions(sess, d.Get("gateway_id").(int))
+	if err != nil {
+		return fmt.Errorf("Error deleting network gateway vlan association: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerNetworkGatewayVlanAssociationExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkGatewayVlanService(sess)
+
+	associationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid network gateway vlan association ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(associationId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving network gateway vlan association: %s", err)
+	}
+	return result.Id != nil && *result.Id == associationId, nil
+}
+
+func setNetworkGatewayVlanBypass(sess *session.Session, gatewayId, associationId int, bypass bool) error {
+	service := services.GetNetworkGatewayVlanService(sess).Id(associationId)
+
+	var err error
+	if bypass {
+		err = service.Bypass()
+	} else {
+		err = service.Unbypass()
+	}
+	if err != nil {
+		return err
+	}
+
+	return waitForNetworkGatewayTransactions(sess, gatewayId)
+}
+
+// waitForNetworkGatewayTransactions waits until the members of a gateway have no active transaction,
+// as the vlan changes are applied to the gateway appliances by network transactions.
+func waitForNetworkGatewayTransactions(sess *session.Session, gatewayId int) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			members, err := services.GetNetworkGatewayService(sess).Id(gatewayId).Mask("hardwareId").GetMembers()
+			if err != nil {
+				return nil, "", err
+			}
+
+			for _, member := range members {
+				if member.HardwareId == nil {
+					continue
+				}
+
+				hardware, err := services.GetHardwareServerService(sess).
+					Id(*member.HardwareId).
+					Mask("id,activeTransaction[id]").
+					GetObject()
+				if err != nil {
+					return nil, "", err
+				}
+
+				if hardware.ActiveTransaction != nil {
+					log.Printf("[DEBUG] Network gateway member %d has an active transaction", *member.HardwareId)
+					return members, "pending", nil
+				}
+			}
+
+			return members, "complete", nil
+		},
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
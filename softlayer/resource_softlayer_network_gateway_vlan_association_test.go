@@ -0,0 +1,105 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+// The network gateway must exist in the account, gateway appliances can't be ordered by the provider
+func TestAccSoftLayerNetworkGatewayVlanAssociation_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerNetworkGatewayVlanAssociationConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerNetworkGatewayVlanAssociationExists(
+						"softlayer_network_gateway_vlan_association.tfacc_association"),
+					testAccCheckSoftLayerResources("softlayer_network_gateway_vlan_association.tfacc_association",
+						"gateway_id", "data.softlayer_network_gateway.tfacc_gateway", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway_vlan_association.tfacc_association", "bypass", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerNetworkGatewayVlanAssociationConfig_routed,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_network_gateway_vlan_association.tfacc_association", "bypass", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerNetworkGatewayVlanAssociationExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		associationId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkGatewayVlanService(testAccProvider.Meta().(*session.Session))
+		foundAssociation, err := service.Id(associationId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundAssociation.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerNetworkGatewayVlanAssociationConfig_basic = `
+data "softlayer_network_gateway" "tfacc_gateway" {
+    name = "tfacc-gateway"
+}
+
+resource "softlayer_vlan" "tfacc_gateway_vlan" {
+    name = "tfacc_gateway_vlan"
+    datacenter = "dal06"
+    type = "PRIVATE"
+    subnet_size = 8
+}
+
+resource "softlayer_network_gateway_vlan_association" "tfacc_association" {
+    gateway_id = "${data.softlayer_network_gateway.tfacc_gateway.id}"
+    network_vlan_id = "${softlayer_vlan.tfacc_gateway_vlan.id}"
+}`
+
+const testAccCheckSoftLayerNetworkGatewayVlanAssociationConfig_routed = `
+data "softlayer_network_gateway" "tfacc_gateway" {
+    name = "tfacc-gateway"
+}
+
+resource "softlayer_vlan" "tfacc_gateway_vlan" {
+    name = "tfacc_gateway_vlan"
+    datacenter = "dal06"
+    type = "PRIVATE"
+    subnet_size = 8
+}
+
+resource "softlayer_network_gateway_vlan_association" "tfacc_association" {
+    gateway_id = "${data.softlayer_network_gateway.tfacc_gateway.id}"
+    network_vlan_id = "${softlayer_vlan.tfacc_gateway_vlan.id}"
+    bypass = false
+}`
@@ -3,6 +3,7 @@ package softlayer
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"time"
 
@@ -11,11 +12,21 @@ import (
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/filter"
 	"github.com/softlayer/softlayer-go/helpers/order"
+	"github.com/softlayer/softlayer-go/helpers/product"
 	"github.com/softlayer/softlayer-go/services"
 	"github.com/softlayer/softlayer-go/session"
 	"github.com/softlayer/softlayer-go/sl"
 )
 
+const (
+	// Object storage is sold as an item of the additional products package
+	objectStoragePackageId    = 0
+	objectStorageCategoryCode = "hub"
+	objectStorageItemKeyName  = "PAY_AS_YOU_GO"
+
+	objectStorageAccountMask = "id,username,notes,billingItem[id]"
+)
+
 func resourceSoftLayerObjectStorageAccount() *schema.Resource {
 	return &schema.Resource{
 		Create:   resourceSoftLayerObjectStorageAccountCreate,
@@ -38,6 +49,40 @@ func resourceSoftLayerObjectStorageAccount() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"cancel_on_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"username": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"api_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"endpoints": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_auth_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_auth_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -53,15 +98,18 @@ func resourceSoftLayerObjectStorageAccountCreate(d *schema.ResourceData, meta in
 	}
 
 	if len(objectStorageAccounts) == 0 {
+		price, err := findObjectStorageAccountPrice(sess)
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error on create: %s", err)
+		}
+
 		// Order the account
 		productOrderService := services.GetProductOrderService(sess)
 
 		receipt, err := productOrderService.PlaceOrder(&datatypes.Container_Product_Order{
 			Quantity:  sl.Int(1),
-			PackageId: sl.Int(0),
-			Prices: []datatypes.Product_Item_Price{
-				{Id: sl.Int(30920)},
-			},
+			PackageId: sl.Int(objectStoragePackageId),
+			Prices:    []datatypes.Product_Item_Price{price},
 		}, sl.Bool(false))
 		if err != nil {
 			return fmt.Errorf(
@@ -92,7 +140,14 @@ func resourceSoftLayerObjectStorageAccountCreate(d *schema.ResourceData, meta in
 	d.SetId(*objectStorageAccounts[0].Username)
 	d.Set("name", *objectStorageAccounts[0].Username)
 
-	return nil
+	if note, ok := d.GetOk("local_note"); ok {
+		err = updateObjectStorageAccountNote(sess, *objectStorageAccounts[0].Id, note.(string))
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error on create: %s", err)
+		}
+	}
+
+	return resourceSoftLayerObjectStorageAccountRead(d, meta)
 }
 
 func WaitForOrderCompletion(
@@ -131,39 +186,184 @@ func WaitForOrderCompletion(
 
 func resourceSoftLayerObjectStorageAccountRead(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(*session.Session)
-	accountService := services.GetAccountService(sess)
 	accountName := d.Id()
-	d.Set("name", accountName)
 
-	// Check if an object storage account exists
-	objectStorageAccounts, err := accountService.Filter(
-		filter.Path("username").Eq(accountName).Build(),
-	).GetHubNetworkStorage()
+	objectStorageAccount, found, err := getObjectStorageAccount(sess, accountName)
 	if err != nil {
 		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on Read: %s", err)
 	}
 
-	for _, objectStorageAccount := range objectStorageAccounts {
-		if *objectStorageAccount.Username == accountName {
-			return nil
-		}
+	if !found {
+		log.Printf("[WARN] Object storage account %s not found, removing it from state", accountName)
+		d.SetId("")
+		return nil
 	}
 
-	return fmt.Errorf("resource_softlayer_objectstorage_account: Could not find account %s", accountName)
+	d.Set("name", accountName)
+	d.Set("local_note", sl.Get(objectStorageAccount.Notes, ""))
+
+	username, apiKey, err := getObjectStorageCredentials(sess, *objectStorageAccount.Id)
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error retrieving credentials: %s", err)
+	}
+	d.Set("username", username)
+	d.Set("api_key", apiKey)
+
+	connectionInfo, err := services.GetNetworkStorageService(sess).Id(*objectStorageAccount.Id).
+		GetObjectStorageConnectionInformation()
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error retrieving endpoints: %s", err)
+	}
+
+	endpoints := make([]map[string]interface{}, 0, len(connectionInfo))
+	for _, info := range connectionInfo {
+		endpoints = append(endpoints, map[string]interface{}{
+			"datacenter":       sl.Get(info.DatacenterShortName, sl.Get(info.Datacenter, "")),
+			"public_auth_url":  sl.Get(info.PublicEndpoint, ""),
+			"private_auth_url": sl.Get(info.PrivateEndpoint, ""),
+		})
+	}
+	d.Set("endpoints", endpoints)
+
+	return nil
 }
 
 func resourceSoftLayerObjectStorageAccountUpdate(d *schema.ResourceData, meta interface{}) error {
-	// Nothing to update for now. Not supported.
-	return nil
+	sess := meta.(*session.Session)
+
+	if d.HasChange("local_note") {
+		objectStorageAccount, found, err := getObjectStorageAccount(sess, d.Id())
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error on update: %s", err)
+		}
+
+		if !found {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Could not find account %s", d.Id())
+		}
+
+		err = updateObjectStorageAccountNote(sess, *objectStorageAccount.Id, d.Get("local_note").(string))
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error on update: %s", err)
+		}
+	}
+
+	return resourceSoftLayerObjectStorageAccountRead(d, meta)
 }
 
 func resourceSoftLayerObjectStorageAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	// Delete is not supported for now.
+	sess := meta.(*session.Session)
+
+	// The account may have been adopted rather than ordered by this resource, and it may hold data
+	// used outside of Terraform. It is only cancelled when explicitly requested.
+	if !d.Get("cancel_on_destroy").(bool) {
+		log.Printf("[WARN] Object storage account %s is removed from state but not cancelled. "+
+			"Set cancel_on_destroy to cancel it", d.Id())
+		return nil
+	}
+
+	objectStorageAccount, found, err := getObjectStorageAccount(sess, d.Id())
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on delete: %s", err)
+	}
+
+	if !found || objectStorageAccount.BillingItem == nil || objectStorageAccount.BillingItem.Id == nil {
+		return nil
+	}
+
+	log.Printf("[INFO] Cancelling object storage account %s", d.Id())
+
+	_, err = services.GetBillingItemService(sess).Id(*objectStorageAccount.BillingItem.Id).CancelItem(
+		sl.Bool(true), sl.Bool(true), sl.String("No longer required"), sl.String("Please cancel this object storage account"),
+	)
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error cancelling account %s: %s", d.Id(), err)
+	}
+
 	return nil
 }
 
 func resourceSoftLayerObjectStorageAccountExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	err := resourceSoftLayerObjectStorageAccountRead(d, meta)
+	sess := meta.(*session.Session)
+
+	_, found, err := getObjectStorageAccount(sess, d.Id())
+	if err != nil {
+		return false, fmt.Errorf("resource_softlayer_objectstorage_account: Error on Exists: %s", err)
+	}
+
+	return found, nil
+}
+
+// getObjectStorageAccount looks up an object storage account by name. found is false when the account
+// does not exist anymore.
+func getObjectStorageAccount(sess *session.Session, accountName string) (
+	objectStorageAccount datatypes.Network_Storage, found bool, err error) {
+
+	objectStorageAccounts, err := services.GetAccountService(sess).
+		Mask(objectStorageAccountMask).
+		Filter(filter.Path("hubNetworkStorage.username").Eq(accountName).Build()).
+		GetHubNetworkStorage()
+	if err != nil {
+		return datatypes.Network_Storage{}, false, err
+	}
+
+	for _, objectStorageAccount := range objectStorageAccounts {
+		if sl.Get(objectStorageAccount.Username, "").(string) == accountName {
+			return objectStorageAccount, true, nil
+		}
+	}
+
+	return datatypes.Network_Storage{}, false, nil
+}
+
+// getObjectStorageCredentials returns the Swift username and API key of an object storage account
+func getObjectStorageCredentials(sess *session.Session, storageId int) (username string, apiKey string, err error) {
+	credentials, err := services.GetNetworkStorageService(sess).Id(storageId).
+		Mask("id,username,password").
+		GetCredentials()
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, credential := range credentials {
+		if credential.Username != nil && credential.Password != nil {
+			return *credential.Username, *credential.Password, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("No credentials found for object storage account %d", storageId)
+}
+
+func updateObjectStorageAccountNote(sess *session.Session, storageId int, note string) error {
+	_, err := services.GetNetworkStorageService(sess).Id(storageId).
+		EditObject(&datatypes.Network_Storage{Notes: sl.String(note)})
+	return err
+}
+
+// findObjectStorageAccountPrice returns the standard price of the pay as you go object storage item
+func findObjectStorageAccountPrice(sess *session.Session) (datatypes.Product_Item_Price, error) {
+	productItems, err := product.GetPackageProducts(sess, objectStoragePackageId,
+		"id,keyName,prices[id,locationGroupId,categories[categoryCode]]")
+	if err != nil {
+		return datatypes.Product_Item_Price{}, err
+	}
+
+	for _, item := range productItems {
+		if !strings.Contains(sl.Get(item.KeyName, "").(string), objectStorageItemKeyName) {
+			continue
+		}
+
+		for _, price := range item.Prices {
+			// Prices with a location group only apply to specific datacenters
+			if price.LocationGroupId != nil {
+				continue
+			}
+
+			if storagePriceHasCategory(price, objectStorageCategoryCode) {
+				return datatypes.Product_Item_Price{Id: price.Id}, nil
+			}
+		}
+	}
 
-	return err == nil, err
+	return datatypes.Product_Item_Price{},
+		fmt.Errorf("No %s price found for object storage in package %d", objectStorageItemKeyName, objectStoragePackageId)
 }
@@ -2,6 +2,7 @@ package softlayer
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -21,6 +22,20 @@ func TestAccSoftLayerObjectStorageAccount_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSoftLayerObjectStorageAccountExists("softlayer_objectstorage_account.testacc_foobar", &accountName),
 					testAccCheckSoftLayerObjectStorageAccountAttributes(&accountName),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_account.testacc_foobar", "local_note", "terraform object storage"),
+					resource.TestMatchResourceAttr(
+						"softlayer_objectstorage_account.testacc_foobar", "username", regexp.MustCompile(`^.+:.+$`)),
+					resource.TestMatchResourceAttr(
+						"softlayer_objectstorage_account.testacc_foobar", "endpoints.0.public_auth_url", regexp.MustCompile(`^https://`)),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageAccountConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_account.testacc_foobar", "local_note", "updated object storage"),
 				),
 			},
 		},
@@ -62,4 +77,10 @@ func testAccCheckSoftLayerObjectStorageAccountAttributes(accountName *string) re
 
 var testAccCheckSoftLayerObjectStorageAccountConfig_basic = `
 resource "softlayer_objectstorage_account" "testacc_foobar" {
+    local_note = "terraform object storage"
+}`
+
+var testAccCheckSoftLayerObjectStorageAccountConfig_updated = `
+resource "softlayer_objectstorage_account" "testacc_foobar" {
+    local_note = "updated object storage"
 }`
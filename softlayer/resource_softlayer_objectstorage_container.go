@@ -0,0 +1,284 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func resourceSoftLayerObjectStorageContainer() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerObjectStorageContainerCreate,
+		Read:     resourceSoftLayerObjectStorageContainerRead,
+		Update:   resourceSoftLayerObjectStorageContainerUpdate,
+		Delete:   resourceSoftLayerObjectStorageContainerDelete,
+		Exists:   resourceSoftLayerObjectStorageContainerExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					name := v.(string)
+					if name == "" || len(name) > 256 || strings.Contains(name, "/") {
+						errs = append(errs, fmt.Errorf(
+							"%q must be between 1 and 256 characters long and must not contain '/'", k))
+					}
+					return
+				},
+			},
+			"auth_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"read_acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"write_acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"cdn_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"object_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bytes_used": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerObjectStorageContainerCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error creating object storage container: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	headers := http.Header{}
+	swiftMetadataHeaders(swiftContainerMetaPrefix, d.Get("metadata").(map[string]interface{}), headers)
+
+	if readACL, ok := d.GetOk("read_acl"); ok {
+		headers.Set("X-Container-Read", readACL.(string))
+	}
+
+	if writeACL, ok := d.GetOk("write_acl"); ok {
+		headers.Set("X-Container-Write", writeACL.(string))
+	}
+
+	log.Printf("[INFO] Creating object storage container %s", name)
+
+	err = client.createContainer(name, headers)
+	if err != nil {
+		return fmt.Errorf("Error creating object storage container: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", d.Get("account_name").(string), d.Get("datacenter").(string), name))
+
+	if d.Get("cdn_enabled").(bool) {
+		err = client.updateContainer(name, http.Header{"X-Cdn-Enabled": []string{"True"}})
+		if err != nil {
+			return fmt.Errorf("Error enabling CDN on object storage container: %s", err)
+		}
+	}
+
+	return resourceSoftLayerObjectStorageContainerRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageContainerRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error retrieving object storage container: %s", err)
+	}
+
+	headers, err := client.headContainer(d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Error retrieving object storage container: %s", err)
+	}
+
+	d.Set("metadata", readSwiftMetadata(swiftContainerMetaPrefix, headers, d.Get("metadata").(map[string]interface{})))
+	d.Set("read_acl", headers.Get("X-Container-Read"))
+	d.Set("write_acl", headers.Get("X-Container-Write"))
+	d.Set("object_count", headerInt(headers, "X-Container-Object-Count"))
+	d.Set("bytes_used", headerInt(headers, "X-Container-Bytes-Used"))
+
+	if cdnEnabled := headers.Get("X-Cdn-Enabled"); cdnEnabled != "" {
+		d.Set("cdn_enabled", strings.EqualFold(cdnEnabled, "true"))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageContainerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error updating object storage container: %s", err)
+	}
+
+	headers := http.Header{}
+
+	if d.HasChange("metadata") {
+		oldMetadata, newMetadata := d.GetChange("metadata")
+		for key := range oldMetadata.(map[string]interface{}) {
+			if _, ok := newMetadata.(map[string]interface{})[key]; !ok {
+				headers.Set("X-Remove-Container-Meta-"+key, "x")
+			}
+		}
+		swiftMetadataHeaders(swiftContainerMetaPrefix, newMetadata.(map[string]interface{}), headers)
+	}
+
+	for key, header := range map[string]string{"read_acl": "Read", "write_acl": "Write"} {
+		if !d.HasChange(key) {
+			continue
+		}
+
+		if acl := d.Get(key).(string); acl != "" {
+			headers.Set("X-Container-"+header, acl)
+		} else {
+			headers.Set("X-Remove-Container-"+header, "x")
+		}
+	}
+
+	if d.HasChange("cdn_enabled") {
+		if d.Get("cdn_enabled").(bool) {
+			headers.Set("X-Cdn-Enabled", "True")
+		} else {
+			headers.Set("X-Cdn-Enabled", "False")
+		}
+	}
+
+	if len(headers) > 0 {
+		err = client.updateContainer(d.Get("name").(string), headers)
+		if err != nil {
+			return fmt.Errorf("Error updating object storage container: %s", err)
+		}
+	}
+
+	return resourceSoftLayerObjectStorageContainerRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageContainerDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error deleting object storage container: %s", err)
+	}
+
+	name := d.Get("name").(string)
+
+	// Swift refuses to delete a container which still holds objects
+	if d.Get("force_destroy").(bool) {
+		objects, err := client.listObjects(name)
+		if err != nil {
+			return fmt.Errorf("Error listing objects of object storage container %s: %s", name, err)
+		}
+
+		for _, object := range objects {
+			err = client.deleteObject(name, object)
+			if err != nil && !isSwiftNotFound(err) {
+				return fmt.Errorf("Error deleting object %s of object storage container %s: %s", object, name, err)
+			}
+		}
+	}
+
+	err = client.deleteContainer(name)
+	if err != nil && !isSwiftNotFound(err) {
+		if apiErr, ok := err.(swiftError); ok && apiErr.StatusCode == http.StatusConflict {
+			return fmt.Errorf("Error deleting object storage container %s: the container is not empty. "+
+				"Set force_destroy to delete its objects", name)
+		}
+		return fmt.Errorf("Error deleting object storage container: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageContainerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return false, fmt.Errorf("Error retrieving object storage container: %s", err)
+	}
+
+	_, err = client.headContainer(d.Get("name").(string))
+	if err != nil {
+		if isSwiftNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving object storage container: %s", err)
+	}
+
+	return true, nil
+}
+
+// getObjectStorageContainerClient returns a Swift client for the account of the container. The
+// account, datacenter and name are taken from the ID when they are not set, as after an import.
+func getObjectStorageContainerClient(d *schema.ResourceData, meta interface{}) (*swiftClient, error) {
+	sess := meta.(*session.Session)
+
+	if d.Get("name").(string) == "" && d.Id() != "" {
+		accountName, datacenter, name, err := parseObjectStorageContainerId(d.Id())
+		if err != nil {
+			return nil, err
+		}
+		d.Set("account_name", accountName)
+		d.Set("datacenter", datacenter)
+		d.Set("name", name)
+	}
+
+	return getObjectStorageSwiftClient(sess,
+		d.Get("account_name").(string), d.Get("datacenter").(string), d.Get("auth_url").(string))
+}
+
+// parseObjectStorageContainerId splits a container ID of the form <account name>/<datacenter>/<name>
+func parseObjectStorageContainerId(id string) (accountName string, datacenter string, name string, err error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf(
+			"Not a valid object storage container ID, must be <account name>/<datacenter>/<name>: %s", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func headerInt(headers http.Header, name string) int {
+	value, _ := strconv.Atoi(headers.Get(name))
+	return value
+}
@@ -0,0 +1,129 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerObjectStorageContainer_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerObjectStorageContainerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageContainerConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerObjectStorageContainerExists("softlayer_objectstorage_container.artifacts"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.artifacts", "name", "terraform-artifacts"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.artifacts", "datacenter", "dal05"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.artifacts", "metadata.owner", "terraform"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.artifacts", "read_acl", ".r:*"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageContainerConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.artifacts", "metadata.%", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.artifacts", "metadata.env", "test"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.artifacts", "read_acl", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerObjectStorageContainerDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_objectstorage_container" {
+			continue
+		}
+
+		accountName, datacenter, name, err := parseObjectStorageContainerId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client, err := getObjectStorageSwiftClient(testAccProvider.Meta().(*session.Session), accountName, datacenter, "")
+		if err != nil {
+			return err
+		}
+
+		_, err = client.headContainer(name)
+		if err == nil {
+			return fmt.Errorf("Object storage container %s still exists", rs.Primary.ID)
+		}
+
+		if !isSwiftNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerObjectStorageContainerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		accountName, datacenter, name, err := parseObjectStorageContainerId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		client, err := getObjectStorageSwiftClient(testAccProvider.Meta().(*session.Session), accountName, datacenter, "")
+		if err != nil {
+			return err
+		}
+
+		_, err = client.headContainer(name)
+		return err
+	}
+}
+
+const testAccCheckSoftLayerObjectStorageContainerConfig_basic = `
+resource "softlayer_objectstorage_account" "testacc_foobar" {
+}
+
+resource "softlayer_objectstorage_container" "artifacts" {
+    account_name = "${softlayer_objectstorage_account.testacc_foobar.id}"
+    datacenter = "dal05"
+    name = "terraform-artifacts"
+    metadata = {
+        owner = "terraform"
+    }
+    read_acl = ".r:*"
+}`
+
+const testAccCheckSoftLayerObjectStorageContainerConfig_updated = `
+resource "softlayer_objectstorage_account" "testacc_foobar" {
+}
+
+resource "softlayer_objectstorage_container" "artifacts" {
+    account_name = "${softlayer_objectstorage_account.testacc_foobar.id}"
+    datacenter = "dal05"
+    name = "terraform-artifacts"
+    metadata = {
+        env = "test"
+    }
+}`
@@ -0,0 +1,208 @@
+package softlayer
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func resourceSoftLayerObjectStorageObject() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerObjectStorageObjectPut,
+		Read:     resourceSoftLayerObjectStorageObjectRead,
+		Update:   resourceSoftLayerObjectStorageObjectPut,
+		Delete:   resourceSoftLayerObjectStorageObjectDelete,
+		Exists:   resourceSoftLayerObjectStorageObjectExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"container_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"auth_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source"},
+			},
+			"source": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"content"},
+			},
+			"content_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+			"etag": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"content_length": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceSoftLayerObjectStorageObjectPut uploads the object. Swift has no partial update of an
+// object, so updates upload the object again along with all its metadata.
+func resourceSoftLayerObjectStorageObjectPut(d *schema.ResourceData, meta interface{}) error {
+	client, container, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error uploading object storage object: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	headers := http.Header{}
+	swiftMetadataHeaders(swiftObjectMetaPrefix, d.Get("metadata").(map[string]interface{}), headers)
+
+	if contentType, ok := d.GetOk("content_type"); ok {
+		headers.Set("Content-Type", contentType.(string))
+	}
+
+	var body io.ReadSeeker
+	if source, ok := d.GetOk("source"); ok {
+		file, err := os.Open(source.(string))
+		if err != nil {
+			return fmt.Errorf("Error opening object storage object source %s: %s", source.(string), err)
+		}
+		defer file.Close()
+		body = file
+	} else {
+		body = strings.NewReader(d.Get("content").(string))
+	}
+
+	log.Printf("[INFO] Uploading object storage object %s/%s", container, name)
+
+	_, err = client.putObject(container, name, headers, body)
+	if err != nil {
+		return fmt.Errorf("Error uploading object storage object: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("container_id").(string), name))
+
+	return resourceSoftLayerObjectStorageObjectRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageObjectRead(d *schema.ResourceData, meta interface{}) error {
+	client, container, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error retrieving object storage object: %s", err)
+	}
+
+	headers, err := client.headObject(container, d.Get("name").(string))
+	if err != nil {
+		return fmt.Errorf("Error retrieving object storage object: %s", err)
+	}
+
+	etag := strings.Trim(headers.Get("Etag"), `"`)
+
+	d.Set("etag", etag)
+	d.Set("content_type", headers.Get("Content-Type"))
+	d.Set("content_length", headerInt(headers, "Content-Length"))
+	d.Set("metadata", readSwiftMetadata(swiftObjectMetaPrefix, headers, d.Get("metadata").(map[string]interface{})))
+
+	// The ETag of a Swift object is the MD5 of its content. Clear the content when the object was
+	// changed outside of Terraform, so that it is uploaded again.
+	if content, ok := d.GetOk("content"); ok {
+		hash := md5.Sum([]byte(content.(string)))
+		if hex.EncodeToString(hash[:]) != etag {
+			log.Printf("[WARN] Object storage object %s was modified outside of Terraform", d.Id())
+			d.Set("content", "")
+		}
+	}
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageObjectDelete(d *schema.ResourceData, meta interface{}) error {
+	client, container, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error deleting object storage object: %s", err)
+	}
+
+	err = client.deleteObject(container, d.Get("name").(string))
+	if err != nil && !isSwiftNotFound(err) {
+		return fmt.Errorf("Error deleting object storage object: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageObjectExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, container, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return false, fmt.Errorf("Error retrieving object storage object: %s", err)
+	}
+
+	_, err = client.headObject(container, d.Get("name").(string))
+	if err != nil {
+		if isSwiftNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving object storage object: %s", err)
+	}
+
+	return true, nil
+}
+
+// getObjectStorageObjectClient returns a Swift client for the account of the object, along with the
+// name of its container. The container ID and the name are taken from the ID when they are not set,
+// as after an import.
+func getObjectStorageObjectClient(d *schema.ResourceData, meta interface{}) (*swiftClient, string, error) {
+	sess := meta.(*session.Session)
+
+	if d.Get("name").(string) == "" && d.Id() != "" {
+		parts := strings.SplitN(d.Id(), "/", 4)
+		if len(parts) != 4 || parts[3] == "" {
+			return nil, "", fmt.Errorf(
+				"Not a valid object storage object ID, must be <account name>/<datacenter>/<container>/<name>: %s",
+				d.Id())
+		}
+		d.Set("container_id", strings.Join(parts[:3], "/"))
+		d.Set("name", parts[3])
+	}
+
+	accountName, datacenter, container, err := parseObjectStorageContainerId(d.Get("container_id").(string))
+	if err != nil {
+		return nil, "", err
+	}
+
+	client, err := getObjectStorageSwiftClient(sess, accountName, datacenter, d.Get("auth_url").(string))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, container, nil
+}
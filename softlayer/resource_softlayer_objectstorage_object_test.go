@@ -0,0 +1,113 @@
+package softlayer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerObjectStorageObject_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageObjectConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerObjectStorageObjectExists("softlayer_objectstorage_object.readme"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.readme", "content_type", "text/plain"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.readme", "etag", "5eb63bbbe01eeed093cb22bb8f5acdc3"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.readme", "content_length", "11"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.readme", "metadata.build", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageObjectConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.readme", "etag", "fc3ff98e8c6a0d3087d515c0473f8677"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.readme", "metadata.build", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerObjectStorageObjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		parts := strings.SplitN(rs.Primary.ID, "/", 4)
+		if len(parts) != 4 {
+			return fmt.Errorf("Not a valid object storage object ID: %s", rs.Primary.ID)
+		}
+
+		client, err := getObjectStorageSwiftClient(testAccProvider.Meta().(*session.Session), parts[0], parts[1], "")
+		if err != nil {
+			return err
+		}
+
+		_, err = client.headObject(parts[2], parts[3])
+		return err
+	}
+}
+
+const testAccCheckSoftLayerObjectStorageObjectConfig_basic = `
+resource "softlayer_objectstorage_account" "testacc_foobar" {
+}
+
+resource "softlayer_objectstorage_container" "docs" {
+    account_name = "${softlayer_objectstorage_account.testacc_foobar.id}"
+    datacenter = "dal05"
+    name = "terraform-docs"
+    force_destroy = true
+}
+
+resource "softlayer_objectstorage_object" "readme" {
+    container_id = "${softlayer_objectstorage_container.docs.id}"
+    name = "docs/README.txt"
+    content = "hello world"
+    content_type = "text/plain"
+    metadata = {
+        build = "1"
+    }
+}`
+
+const testAccCheckSoftLayerObjectStorageObjectConfig_updated = `
+resource "softlayer_objectstorage_account" "testacc_foobar" {
+}
+
+resource "softlayer_objectstorage_container" "docs" {
+    account_name = "${softlayer_objectstorage_account.testacc_foobar.id}"
+    datacenter = "dal05"
+    name = "terraform-docs"
+    force_destroy = true
+}
+
+resource "softlayer_objectstorage_object" "readme" {
+    container_id = "${softlayer_objectstorage_container.docs.id}"
+    name = "docs/README.txt"
+    content = "hello world!"
+    content_type = "text/plain"
+    metadata = {
+        build = "2"
+    }
+}`
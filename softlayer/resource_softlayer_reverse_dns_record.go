@@ -0,0 +1,238 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerReverseDnsRecord() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerReverseDnsRecordCreate,
+		Read:     resourceSoftLayerReverseDnsRecordRead,
+		Update:   resourceSoftLayerReverseDnsRecordUpdate,
+		Delete:   resourceSoftLayerReverseDnsRecordDelete,
+		Exists:   resourceSoftLayerReverseDnsRecordExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ip_address": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"ip_address_id"},
+			},
+			"ip_address_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"ip_address"},
+			},
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+				// The data of PTR records is stored fully qualified, with a trailing dot
+				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
+					return strings.TrimSuffix(o, ".") == strings.TrimSuffix(n, ".")
+				},
+			},
+			"ttl": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  86400,
+			},
+			"domain_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerReverseDnsRecordCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	ipAddress, err := getReverseDnsRecordIpAddress(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating reverse DNS record: %s", err)
+	}
+
+	record, err := setPtrRecord(sess, ipAddress, d.Get("hostname").(string), d.Get("ttl").(int))
+	if err != nil {
+		return fmt.Errorf("Error creating reverse DNS record: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *record.Id))
+
+	log.Printf("[INFO] Reverse DNS record ID: %s", d.Id())
+
+	return resourceSoftLayerReverseDnsRecordRead(d, meta)
+}
+
+func resourceSoftLayerReverseDnsRecordRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsDomainResourceRecordService(sess)
+
+	recordId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid reverse DNS record ID, must be an integer: %s", err)
+	}
+
+	record, err := service.Id(recordId).Mask("id,host,data,ttl,type,domainId,domain[name]").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving reverse DNS record: %s", err)
+	}
+
+	if sl.Get(record.Type, "") != "ptr" {
+		return fmt.Errorf("DNS record %d is not a PTR record", recordId)
+	}
+
+	d.Set("id", *record.Id)
+	d.Set("hostname", sl.Get(record.Data, ""))
+	d.Set("ttl", sl.Get(record.Ttl, 0))
+	d.Set("domain_id", sl.Get(record.DomainId, 0))
+
+	if record.Domain != nil && record.Domain.Name != nil && record.Host != nil {
+		ipAddress, err := reverseRecordIpAddress(*record.Host, *record.Domain.Name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving reverse DNS record: %s", err)
+		}
+		d.Set("ip_address", ipAddress)
+
+		ip, err := services.GetNetworkSubnetIpAddressService(sess).Mask("id").GetByIpAddress(sl.String(ipAddress))
+		if err != nil {
+			return fmt.Errorf("Error retrieving IP address %s: %s", ipAddress, err)
+		}
+		d.Set("ip_address_id", sl.Get(ip.Id, 0))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerReverseDnsRecordUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	if d.HasChange("hostname") || d.HasChange("ttl") {
+		record, err := setPtrRecord(sess, d.Get("ip_address").(string), d.Get("hostname").(string), d.Get("ttl").(int))
+		if err != nil {
+			return fmt.Errorf("Error updating reverse DNS record: %s", err)
+		}
+
+		// The record is replaced if it was removed outside of Terraform
+		d.SetId(fmt.Sprintf("%d", *record.Id))
+	}
+
+	return resourceSoftLayerReverseDnsRecordRead(d, meta)
+}
+
+func resourceSoftLayerReverseDnsRecordDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsDomainResourceRecordService(sess)
+
+	recordId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid reverse DNS record ID, must be an integer: %s", err)
+	}
+
+	_, err = service.Id(recordId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting reverse DNS record: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerReverseDnsRecordExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetDnsDomainResourceRecordService(sess)
+
+	recordId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid reverse DNS record ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(recordId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving reverse DNS record: %s", err)
+	}
+	return result.Id != nil && *result.Id == recordId, nil
+}
+
+// getReverseDnsRecordIpAddress returns the configured IP address, looking it up when an IP address ID is given
+func getReverseDnsRecordIpAddress(d *schema.ResourceData, sess *session.Session) (string, error) {
+	if ipAddress, ok := d.GetOk("ip_address"); ok {
+		return ipAddress.(string), nil
+	}
+
+	ipAddressId, ok := d.GetOk("ip_address_id")
+	if !ok {
+		return "", fmt.Errorf("One of ip_address or ip_address_id must be set")
+	}
+
+	ip, err := services.GetNetworkSubnetIpAddressService(sess).
+		Id(ipAddressId.(int)).
+		Mask("ipAddress").
+		GetObject()
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving IP address %d: %s", ipAddressId.(int), err)
+	}
+
+	if ip.IpAddress == nil {
+		return "", fmt.Errorf("IP address %d has no address", ipAddressId.(int))
+	}
+
+	return *ip.IpAddress, nil
+}
+
+// setPtrRecord creates the PTR record of an IP address, or edits it when it already exists
+func setPtrRecord(sess *session.Session, ipAddress, hostname string, ttl int) (datatypes.Dns_Domain_ResourceRecord, error) {
+	log.Printf("[INFO] Setting the PTR record of %s to %s", ipAddress, hostname)
+
+	record, err := services.GetDnsDomainService(sess).
+		CreatePtrRecord(sl.String(ipAddress), sl.String(hostname), sl.Int(ttl))
+	if err != nil {
+		return record, err
+	}
+
+	if record.Id == nil {
+		return record, fmt.Errorf("No PTR record was set for %s, only IPv4 addresses are supported", ipAddress)
+	}
+
+	return record, nil
+}
+
+// reverseRecordIpAddress returns the IPv4 address of a PTR record from its host and the name of its
+// reverse zone, e.g. host "4" in zone "3.2.1.in-addr.arpa" is the record of 1.2.3.4.
+func reverseRecordIpAddress(host, zone string) (string, error) {
+	zone = strings.TrimSuffix(zone, ".")
+	if !strings.HasSuffix(zone, ".in-addr.arpa") {
+		return "", fmt.Errorf("%s is not an IPv4 reverse zone", zone)
+	}
+
+	octets := strings.Split(host+"."+strings.TrimSuffix(zone, ".in-addr.arpa"), ".")
+	if len(octets) != 4 {
+		return "", fmt.Errorf("Unable to parse the address of record %s in zone %s", host, zone)
+	}
+
+	for i, j := 0, len(octets)-1; i < j; i, j = i+1, j-1 {
+		octets[i], octets[j] = octets[j], octets[i]
+	}
+
+	return strings.Join(octets, "."), nil
+}
@@ -0,0 +1,123 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerReverseDnsRecord_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerReverseDnsRecordConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerReverseDnsRecordExists("softlayer_reverse_dns_record.ptr"),
+					testAccCheckSoftLayerResources("softlayer_reverse_dns_record.ptr", "ip_address",
+						"softlayer_virtual_guest.ptr_vm", "ipv4_address"),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.ptr", "hostname", "mail.tfacc-example.com."),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.ptr", "ttl", "900"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerReverseDnsRecordConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerReverseDnsRecordExists("softlayer_reverse_dns_record.ptr"),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.ptr", "hostname", "smtp.tfacc-example.com."),
+					resource.TestCheckResourceAttr(
+						"softlayer_reverse_dns_record.ptr", "ttl", "3600"),
+				),
+			},
+		},
+	})
+}
+
+func TestReverseRecordIpAddress(t *testing.T) {
+	ip, err := reverseRecordIpAddress("4", "3.2.1.in-addr.arpa")
+	if err != nil || ip != "1.2.3.4" {
+		t.Errorf("Expected 1.2.3.4, got %s (%v)", ip, err)
+	}
+
+	ip, err = reverseRecordIpAddress("10", "0.168.192.in-addr.arpa.")
+	if err != nil || ip != "192.168.0.10" {
+		t.Errorf("Expected 192.168.0.10, got %s (%v)", ip, err)
+	}
+
+	if _, err = reverseRecordIpAddress("4", "example.com"); err == nil {
+		t.Error("Expected an error for a forward zone")
+	}
+
+	if _, err = reverseRecordIpAddress("4", "2.1.in-addr.arpa"); err == nil {
+		t.Error("Expected an error for an incomplete address")
+	}
+}
+
+func testAccCheckSoftLayerReverseDnsRecordExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		recordId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetDnsDomainResourceRecordService(testAccProvider.Meta().(*session.Session))
+		foundRecord, err := service.Id(recordId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundRecord.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerReverseDnsRecordConfig_vm = `
+resource "softlayer_virtual_guest" "ptr_vm" {
+    name = "ptr-vm"
+    domain = "tfacc-example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+`
+
+const testAccCheckSoftLayerReverseDnsRecordConfig_basic = testAccCheckSoftLayerReverseDnsRecordConfig_vm + `
+resource "softlayer_reverse_dns_record" "ptr" {
+    ip_address_id = "${softlayer_virtual_guest.ptr_vm.ip_address_id}"
+    hostname = "mail.tfacc-example.com."
+    ttl = 900
+}`
+
+const testAccCheckSoftLayerReverseDnsRecordConfig_updated = testAccCheckSoftLayerReverseDnsRecordConfig_vm + `
+resource "softlayer_reverse_dns_record" "ptr" {
+    ip_address_id = "${softlayer_virtual_guest.ptr_vm.ip_address_id}"
+    hostname = "smtp.tfacc-example.com."
+    ttl = 3600
+}`
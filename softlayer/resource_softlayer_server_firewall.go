@@ -0,0 +1,504 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	ServerFirewallMask = "id,status,guestNetworkComponent[guestId],networkComponent[hardwareId]," +
+		"rules[orderValue,action,sourceIpAddress,sourceIpCidr,destinationPortRangeStart,destinationPortRangeEnd,protocol,notes]"
+)
+
+func resourceSoftLayerServerFirewall() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerServerFirewallCreate,
+		Read:     resourceSoftLayerServerFirewallRead,
+		Update:   resourceSoftLayerServerFirewallUpdate,
+		Delete:   resourceSoftLayerServerFirewallDelete,
+		Exists:   resourceSoftLayerServerFirewallExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"virtual_guest_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"hardware_id"},
+			},
+			"hardware_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_guest_id"},
+			},
+			"port_speed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"order": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+								action := v.(string)
+								if action != "permit" && action != "deny" {
+									errs = append(errs, errors.New(
+										"action should be either 'permit' or 'deny'"))
+								}
+								return
+							},
+						},
+						"src_cidr": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "any",
+						},
+						"dst_port_range_start": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"dst_port_range_end": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"protocol": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateFirewallRuleProtocol,
+						},
+						"notes": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerServerFirewallCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	guestId := d.Get("virtual_guest_id").(int)
+	hardwareId := d.Get("hardware_id").(int)
+
+	if guestId == 0 && hardwareId == 0 {
+		return errors.New("Error creating server firewall: one of virtual_guest_id or hardware_id is required")
+	}
+
+	productOrderContainer, err := buildServerFirewallProductOrderContainer(sess, guestId, hardwareId)
+	if err != nil {
+		return fmt.Errorf("Error creating server firewall: %s", err)
+	}
+
+	log.Println("[INFO] Creating server firewall")
+
+	_, err = services.GetProductOrderService(sess).PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of server firewall: %s", err)
+	}
+
+	firewall, err := findServerFirewall(sess, guestId, hardwareId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of server firewall: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *firewall.Id))
+
+	if d.Get("rule").(*schema.Set).Len() > 0 {
+		err = applyServerFirewallRules(sess, *firewall.Id, d.Get("rule").(*schema.Set).List())
+		if err != nil {
+			return fmt.Errorf("Error setting server firewall rules: %s", err)
+		}
+	}
+
+	return resourceSoftLayerServerFirewallRead(d, meta)
+}
+
+func resourceSoftLayerServerFirewallRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkComponentFirewallService(sess)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid server firewall ID, must be an integer: %s", err)
+	}
+
+	firewall, err := service.Id(firewallId).Mask(ServerFirewallMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving server firewall: %s", err)
+	}
+
+	d.Set("id", *firewall.Id)
+	d.Set("status", sl.Get(firewall.Status, ""))
+
+	if firewall.GuestNetworkComponent != nil {
+		d.Set("virtual_guest_id", sl.Get(firewall.GuestNetworkComponent.GuestId, 0))
+	}
+
+	if firewall.NetworkComponent != nil {
+		d.Set("hardware_id", sl.Get(firewall.NetworkComponent.HardwareId, 0))
+	}
+
+	speed, err := getServerPortSpeed(sess, d.Get("virtual_guest_id").(int), d.Get("hardware_id").(int))
+	if err != nil {
+		return fmt.Errorf("Error retrieving server firewall: %s", err)
+	}
+	d.Set("port_speed", speed)
+
+	d.Set("rule", flattenServerFirewallRules(firewall.Rules, d.Get("rule").(*schema.Set).List()))
+
+	return nil
+}
+
+func resourceSoftLayerServerFirewallUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid server firewall ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("rule") {
+		err = applyServerFirewallRules(sess, firewallId, d.Get("rule").(*schema.Set).List())
+		if err != nil {
+			return fmt.Errorf("Error updating server firewall rules: %s", err)
+		}
+	}
+
+	return resourceSoftLayerServerFirewallRead(d, meta)
+}
+
+func resourceSoftLayerServerFirewallDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkComponentFirewallService(sess)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid server firewall ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(firewallId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting server firewall: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+
+	return err
+}
+
+func resourceSoftLayerServerFirewallExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkComponentFirewallService(sess)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid server firewall ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(firewallId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving server firewall: %s", err)
+	}
+	return result.Id != nil && *result.Id == firewallId, nil
+}
+
+// getServerPortSpeed returns the speed in Mbps of the public port of a virtual guest or a hardware
+func getServerPortSpeed(sess *session.Session, guestId, hardwareId int) (int, error) {
+	if guestId != 0 {
+		guest, err := services.GetVirtualGuestService(sess).
+			Id(guestId).
+			Mask("id,primaryNetworkComponent[maxSpeed]").
+			GetObject()
+		if err != nil {
+			return 0, err
+		}
+		if guest.PrimaryNetworkComponent == nil || guest.PrimaryNetworkComponent.MaxSpeed == nil {
+			return 0, fmt.Errorf("Virtual guest %d has no public network component", guestId)
+		}
+		return *guest.PrimaryNetworkComponent.MaxSpeed, nil
+	}
+
+	hardware, err := services.GetHardwareService(sess).
+		Id(hardwareId).
+		Mask("id,primaryNetworkComponent[maxSpeed]").
+		GetObject()
+	if err != nil {
+		return 0, err
+	}
+	if hardware.PrimaryNetworkComponent == nil || hardware.PrimaryNetworkComponent.MaxSpeed == nil {
+		return 0, fmt.Errorf("Hardware %d has no public network component", hardwareId)
+	}
+	return *hardware.PrimaryNetworkComponent.MaxSpeed, nil
+}
+
+func buildServerFirewallProductOrderContainer(sess *session.Session, guestId, hardwareId int) (
+	*datatypes.Container_Product_Order_Network_Protection_Firewall, error) {
+
+	speed, err := getServerPortSpeed(sess, guestId, hardwareId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Get a package
+	pkg, err := product.GetPackageByType(sess, AdditionalServicesPackageType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Get all prices for the package
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Find the firewall price matching the port speed
+	keyName := fmt.Sprintf("%dMBPS_HARDWARE_FIREWALL", speed)
+
+	var firewallItem *datatypes.Product_Item
+	for i, item := range productItems {
+		if item.KeyName != nil && *item.KeyName == keyName && len(item.Prices) > 0 {
+			firewallItem = &productItems[i]
+			break
+		}
+	}
+
+	if firewallItem == nil {
+		return nil, fmt.Errorf("No product items matching %s could be found", keyName)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Protection_Firewall{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: firewallItem.Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+	}
+
+	if guestId != 0 {
+		productOrderContainer.VirtualGuests = []datatypes.Virtual_Guest{{Id: sl.Int(guestId)}}
+	} else {
+		productOrderContainer.Hardware = []datatypes.Hardware{{Id: sl.Int(hardwareId)}}
+	}
+
+	return &productOrderContainer, nil
+}
+
+// findServerFirewall waits for the firewall of a virtual guest or a hardware to be provisioned
+func findServerFirewall(sess *session.Session, guestId, hardwareId int) (datatypes.Network_Component_Firewall, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			var firewall *datatypes.Network_Component_Firewall
+
+			if guestId != 0 {
+				guest, err := services.GetVirtualGuestService(sess).
+					Id(guestId).
+					Mask("id,firewallServiceComponent[id,status]").
+					GetObject()
+				if err != nil {
+					return datatypes.Network_Component_Firewall{}, "", err
+				}
+				firewall = guest.FirewallServiceComponent
+			} else {
+				hardware, err := services.GetHardwareService(sess).
+					Id(hardwareId).
+					Mask("id,firewallServiceComponent[id,status]").
+					GetObject()
+				if err != nil {
+					return datatypes.Network_Component_Firewall{}, "", err
+				}
+				firewall = hardware.FirewallServiceComponent
+			}
+
+			if firewall == nil || firewall.Id == nil || sl.Get(firewall.Status, "") == "pending" {
+				return nil, "pending", nil
+			}
+			return *firewall, "complete", nil
+		},
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Component_Firewall{}, err
+	}
+
+	var result, ok = pendingResult.(datatypes.Network_Component_Firewall)
+
+	if ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Component_Firewall{}, errors.New("Cannot find the server firewall")
+}
+
+// applyServerFirewallRules replaces the rules of a server firewall and waits until they are applied
+func applyServerFirewallRules(sess *session.Session, firewallId int, rules []interface{}) error {
+	expanded, err := expandServerFirewallRules(rules)
+	if err != nil {
+		return err
+	}
+
+	return submitFirewallUpdateRequest(sess, datatypes.Network_Firewall_Update_Request{
+		NetworkComponentFirewallId: sl.Int(firewallId),
+		Rules:                      expanded,
+	})
+}
+
+type serverFirewallRulesByOrder []interface{}
+
+func (r serverFirewallRulesByOrder) Len() int      { return len(r) }
+func (r serverFirewallRulesByOrder) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r serverFirewallRulesByOrder) Less(i, j int) bool {
+	return r[i].(map[string]interface{})["order"].(int) < r[j].(map[string]interface{})["order"].(int)
+}
+
+// expandServerFirewallRules converts configured rules to update request rules, sorted by their order.
+// The inbound rules apply to any destination address of the server.
+func expandServerFirewallRules(configured []interface{}) ([]datatypes.Network_Firewall_Update_Request_Rule, error) {
+	sorted := make([]interface{}, len(configured))
+	copy(sorted, configured)
+	sort.Sort(serverFirewallRulesByOrder(sorted))
+
+	rules := make([]datatypes.Network_Firewall_Update_Request_Rule, 0, len(sorted))
+	for i, r := range sorted {
+		ruleMap := r.(map[string]interface{})
+		rule := datatypes.Network_Firewall_Update_Request_Rule{
+			OrderValue:           sl.Int(i + 1),
+			Action:               sl.String(ruleMap["action"].(string)),
+			SourceIpAddress:      sl.String("any"),
+			DestinationIpAddress: sl.String("any"),
+			Protocol:             sl.String(ruleMap["protocol"].(string)),
+			Version:              sl.Int(4),
+		}
+
+		if srcCidr := ruleMap["src_cidr"].(string); srcCidr != "" && srcCidr != "any" {
+			parts := strings.Split(srcCidr, "/")
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("Unable to parse the source CIDR of rule %d: %s", ruleMap["order"].(int), srcCidr)
+			}
+			cidr, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("Unable to parse the source CIDR of rule %d: %s", ruleMap["order"].(int), srcCidr)
+			}
+			rule.SourceIpAddress = sl.String(parts[0])
+			rule.SourceIpCidr = sl.Int(cidr)
+		}
+
+		if port := ruleMap["dst_port_range_start"].(int); port > 0 {
+			rule.DestinationPortRangeStart = sl.Int(port)
+		}
+
+		if port := ruleMap["dst_port_range_end"].(int); port > 0 {
+			rule.DestinationPortRangeEnd = sl.Int(port)
+		}
+
+		if notes := ruleMap["notes"].(string); notes != "" {
+			rule.Notes = sl.String(notes)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+type serverFirewallRunningRulesByOrder []datatypes.Network_Component_Firewall_Rule
+
+func (r serverFirewallRunningRulesByOrder) Len() int      { return len(r) }
+func (r serverFirewallRunningRulesByOrder) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r serverFirewallRunningRulesByOrder) Less(i, j int) bool {
+	return sl.Get(r[i].OrderValue, 0).(int) < sl.Get(r[j].OrderValue, 0).(int)
+}
+
+// flattenServerFirewallRules converts the running rules of a server firewall to rules of the resource.
+// The running rules are numbered from 1, so the configured order values are given back by position,
+// which keeps the diff of a reordering to the rules that moved.
+func flattenServerFirewallRules(running []datatypes.Network_Component_Firewall_Rule, configured []interface{}) []map[string]interface{} {
+	sort.Sort(serverFirewallRunningRulesByOrder(running))
+
+	orders := make([]int, 0, len(configured))
+	for _, r := range configured {
+		orders = append(orders, r.(map[string]interface{})["order"].(int))
+	}
+	sort.Ints(orders)
+
+	flattened := make([]map[string]interface{}, 0, len(running))
+	for i, rule := range running {
+		order := i + 1
+		if i < len(orders) {
+			order = orders[i]
+		} else if len(orders) > 0 {
+			order = orders[len(orders)-1] + i - len(orders) + 1
+		}
+
+		srcCidr := "any"
+		srcAddress := sl.Get(rule.SourceIpAddress, "any").(string)
+		srcPrefix := sl.Get(rule.SourceIpCidr, 0).(int)
+		if srcAddress != "any" && !(srcAddress == "0.0.0.0" && srcPrefix == 0) {
+			srcCidr = fmt.Sprintf("%s/%d", srcAddress, srcPrefix)
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"order":                order,
+			"action":               sl.Get(rule.Action, ""),
+			"src_cidr":             srcCidr,
+			"dst_port_range_start": sl.Get(rule.DestinationPortRangeStart, 0),
+			"dst_port_range_end":   sl.Get(rule.DestinationPortRangeEnd, 0),
+			"protocol":             sl.Get(rule.Protocol, ""),
+			"notes":                sl.Get(rule.Notes, ""),
+		})
+	}
+
+	return flattened
+}
@@ -0,0 +1,217 @@
+package softlayer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerServerFirewall_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerServerFirewallConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerServerFirewallExists("softlayer_server_firewall.bastion"),
+					testAccCheckSoftLayerResources("softlayer_server_firewall.bastion", "virtual_guest_id",
+						"softlayer_virtual_guest.bastion", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_server_firewall.bastion", "port_speed", "100"),
+					resource.TestCheckResourceAttr(
+						"softlayer_server_firewall.bastion", "rule.#", "2"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerServerFirewallConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_server_firewall.bastion", "rule.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestServerFirewallRules(t *testing.T) {
+	configured := []interface{}{
+		map[string]interface{}{
+			"order":                30,
+			"action":               "deny",
+			"src_cidr":             "any",
+			"dst_port_range_start": 0,
+			"dst_port_range_end":   0,
+			"protocol":             "icmp",
+			"notes":                "",
+		},
+		map[string]interface{}{
+			"order":                10,
+			"action":               "permit",
+			"src_cidr":             "10.1.1.0/24",
+			"dst_port_range_start": 22,
+			"dst_port_range_end":   22,
+			"protocol":             "tcp",
+			"notes":                "ssh",
+		},
+	}
+
+	rules, err := expandServerFirewallRules(configured)
+	if err != nil {
+		t.Fatalf("Error expanding rules: %s", err)
+	}
+
+	if *rules[0].OrderValue != 1 || *rules[0].SourceIpAddress != "10.1.1.0" || *rules[0].SourceIpCidr != 24 ||
+		*rules[1].OrderValue != 2 || *rules[1].SourceIpAddress != "any" || rules[1].SourceIpCidr != nil {
+		t.Fatalf("Unexpected expanded rules %+v", rules)
+	}
+
+	running := []datatypes.Network_Component_Firewall_Rule{}
+	for i := len(rules) - 1; i >= 0; i-- {
+		running = append(running, datatypes.Network_Component_Firewall_Rule{
+			OrderValue:                rules[i].OrderValue,
+			Action:                    rules[i].Action,
+			SourceIpAddress:           rules[i].SourceIpAddress,
+			SourceIpCidr:              rules[i].SourceIpCidr,
+			DestinationPortRangeStart: rules[i].DestinationPortRangeStart,
+			DestinationPortRangeEnd:   rules[i].DestinationPortRangeEnd,
+			Protocol:                  rules[i].Protocol,
+			Notes:                     rules[i].Notes,
+		})
+	}
+
+	// The configured order values are given back to the running rules by position
+	flattened := flattenServerFirewallRules(running, configured)
+	expected := []interface{}{configured[1], configured[0]}
+	for i, rule := range flattened {
+		for key, value := range rule {
+			if !reflect.DeepEqual(expected[i].(map[string]interface{})[key], value) {
+				t.Fatalf("Unexpected %s of flattened rule %d: %v", key, i, value)
+			}
+		}
+	}
+
+	if _, err := expandServerFirewallRules([]interface{}{
+		map[string]interface{}{
+			"order":                1,
+			"action":               "permit",
+			"src_cidr":             "10.1.1.0",
+			"dst_port_range_start": 0,
+			"dst_port_range_end":   0,
+			"protocol":             "tcp",
+			"notes":                "",
+		},
+	}); err == nil {
+		t.Fatal("Expected an error for a source without prefix length")
+	}
+}
+
+func testAccCheckSoftLayerServerFirewallExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		firewallId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkComponentFirewallService(testAccProvider.Meta().(*session.Session))
+		foundFirewall, err := service.Id(firewallId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundFirewall.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerServerFirewallConfig_basic = `
+resource "softlayer_virtual_guest" "bastion" {
+    name = "bastion"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_server_firewall" "bastion" {
+    virtual_guest_id = "${softlayer_virtual_guest.bastion.id}"
+    rule {
+        order = 10
+        action = "permit"
+        src_cidr = "10.1.1.0/24"
+        dst_port_range_start = 22
+        dst_port_range_end = 22
+        protocol = "tcp"
+        notes = "Permit ssh"
+    }
+    rule {
+        order = 20
+        action = "deny"
+        protocol = "icmp"
+    }
+}`
+
+const testAccCheckSoftLayerServerFirewallConfig_updated = `
+resource "softlayer_virtual_guest" "bastion" {
+    name = "bastion"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_server_firewall" "bastion" {
+    virtual_guest_id = "${softlayer_virtual_guest.bastion.id}"
+    rule {
+        order = 10
+        action = "permit"
+        src_cidr = "10.1.1.0/24"
+        dst_port_range_start = 22
+        dst_port_range_end = 22
+        protocol = "tcp"
+        notes = "Permit ssh"
+    }
+    rule {
+        order = 15
+        action = "permit"
+        dst_port_range_start = 443
+        dst_port_range_end = 443
+        protocol = "tcp"
+    }
+    rule {
+        order = 20
+        action = "deny"
+        protocol = "icmp"
+    }
+}`
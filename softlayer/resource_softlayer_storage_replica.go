@@ -0,0 +1,286 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	storageReplicaOriginMask = "id,capacityGb,iops,storageTierLevel,storageType[keyName],osType[id,keyName]," +
+		"schedules[id,type[keyname]]"
+	storageReplicaMask = "id,username,capacityGb,serviceResource[datacenter[name]],parentVolume[id]"
+)
+
+func resourceSoftLayerStorageReplica() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerStorageReplicaCreate,
+		Read:     resourceSoftLayerStorageReplicaRead,
+		Delete:   resourceSoftLayerStorageReplicaDelete,
+		Exists:   resourceSoftLayerStorageReplicaExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"storage_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schedule_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					scheduleType := v.(string)
+					for _, t := range storageScheduleTypes {
+						if scheduleType == t {
+							return
+						}
+					}
+					errs = append(errs, fmt.Errorf("%s is not one of the valid schedule types: %s",
+						scheduleType, strings.Join(storageScheduleTypes, ", ")))
+					return
+				},
+			},
+			"capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"replication_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerStorageReplicaCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+	storageId := d.Get("storage_id").(int)
+	scheduleType := d.Get("schedule_type").(string)
+	datacenter := d.Get("datacenter").(string)
+
+	origin, err := service.Id(storageId).Mask(storageReplicaOriginMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage %d: %s", storageId, err)
+	}
+
+	// Replication follows the snapshots taken by one of the schedules of the origin volume
+	schedule, ok := getStorageSnapshotSchedule(origin, scheduleType)
+	if !ok {
+		return fmt.Errorf("Storage %d has no %s snapshot schedule to replicate", storageId, scheduleType)
+	}
+
+	targets, err := service.Id(storageId).Mask("id,name").GetValidReplicationTargetDatacenterLocations()
+	if err != nil {
+		return fmt.Errorf("Error retrieving the replication targets of storage %d: %s", storageId, err)
+	}
+
+	var dc datatypes.Location
+	validTargets := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if sl.Get(target.Name, "").(string) == datacenter {
+			dc = target
+		}
+		validTargets = append(validTargets, sl.Get(target.Name, "").(string))
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("%s is not a valid replication target of storage %d. Valid datacenters are: %s",
+			datacenter, storageId, strings.Join(validTargets, ", "))
+	}
+
+	storageType, block, iops := getStorageVolumeSettings(origin)
+
+	pkg, productItems, err := getStoragePackageItems(sess, storageType)
+	if err != nil {
+		return fmt.Errorf("Error creating storage replica: %s", err)
+	}
+
+	selectors, err := getStorageVolumeSelectors(storageType, block, sl.Get(origin.CapacityGb, 0).(int), iops, false)
+	if err != nil {
+		return fmt.Errorf("Error creating storage replica: %s", err)
+	}
+
+	restrictionType, restrictionValue := getStorageRestriction(storageType, iops)
+	selectors = append(selectors, storagePriceSelector{
+		categoryCode:     "performance_storage_replication",
+		restrictionType:  restrictionType,
+		restrictionValue: restrictionValue,
+	})
+
+	prices, err := selectStoragePrices(productItems, selectors)
+	if err != nil {
+		return fmt.Errorf("Error creating storage replica: %s", err)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Storage_Enterprise{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Location:  sl.String(strconv.Itoa(*dc.Id)),
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+		},
+		OriginVolumeId:         sl.Int(storageId),
+		OriginVolumeScheduleId: schedule.Id,
+	}
+
+	if block {
+		productOrderContainer.OsFormatType = origin.OsType
+	}
+
+	log.Printf("[INFO] Creating replica of storage %d in %s", storageId, datacenter)
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+
+	replica, err := findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *replica.Id))
+	log.Printf("[INFO] Storage Replica ID: %s", d.Id())
+
+	_, err = waitForStorageAvailable(sess, *replica.Id)
+	if err != nil {
+		return fmt.Errorf("Error waiting for storage replica (%s) to become available: %s", d.Id(), err)
+	}
+
+	return resourceSoftLayerStorageReplicaRead(d, meta)
+}
+
+func resourceSoftLayerStorageReplicaRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+
+	replicaId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid storage replica ID, must be an integer: %s", err)
+	}
+
+	replica, err := service.Id(replicaId).Mask(storageReplicaMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage replica: %s", err)
+	}
+
+	d.Set("id", *replica.Id)
+	d.Set("volume_name", sl.Get(replica.Username, ""))
+	d.Set("capacity", sl.Get(replica.CapacityGb, 0))
+
+	if replica.ServiceResource != nil && replica.ServiceResource.Datacenter != nil {
+		d.Set("datacenter", sl.Get(replica.ServiceResource.Datacenter.Name, ""))
+	}
+
+	// The origin volume is the parent of the replica, which sets storage_id of imported replicas
+	if replica.ParentVolume != nil && replica.ParentVolume.Id != nil {
+		d.Set("storage_id", *replica.ParentVolume.Id)
+	}
+
+	if storageId, ok := d.GetOk("storage_id"); ok {
+		status, err := service.Id(storageId.(int)).GetReplicationStatus()
+		if err != nil {
+			return fmt.Errorf("Error retrieving the replication status of storage %d: %s", storageId.(int), err)
+		}
+		d.Set("replication_status", status)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageReplicaDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+
+	replicaId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid storage replica ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(replicaId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting storage replica: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelItem(
+		sl.Bool(true), sl.Bool(true), sl.String("No longer required"), sl.String("Please cancel this storage replica"),
+	)
+	if err != nil {
+		return fmt.Errorf("Error canceling the storage replica (%d): %s", replicaId, err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageReplicaExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageService(sess)
+
+	replicaId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid storage replica ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(replicaId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving storage replica: %s", err)
+	}
+
+	return result.Id != nil && *result.Id == replicaId, nil
+}
+
+// getStorageVolumeSettings returns the storage type, the protocol and the IOPS of an existing storage
+// volume, in the form expected by getStorageVolumeSelectors.
+func getStorageVolumeSettings(storage datatypes.Network_Storage) (storageType string, block bool, iops float64) {
+	keyName := ""
+	if storage.StorageType != nil {
+		keyName = sl.Get(storage.StorageType.KeyName, "").(string)
+	}
+
+	block = strings.Contains(keyName, "BLOCK") || strings.Contains(keyName, "ISCSI")
+
+	if strings.Contains(keyName, "ENDURANCE") {
+		storageType = EnduranceStorageType
+		for tierIops, tier := range enduranceStorageTiers {
+			if tier.keyName == sl.Get(storage.StorageTierLevel, "").(string) {
+				iops = tierIops
+				break
+			}
+		}
+		return
+	}
+
+	storageType = PerformanceStorageType
+	iops, _ = strconv.ParseFloat(sl.Get(storage.Iops, "0").(string), 64)
+	return
+}
@@ -0,0 +1,87 @@
+package softlayer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerStorageReplica_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageReplicaConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerStorageReplicaExists("softlayer_storage_replica.replica"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "datacenter", "dal09"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "schedule_type", "HOURLY"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "capacity", "20"),
+					resource.TestMatchResourceAttr(
+						"softlayer_storage_replica.replica", "volume_name", regexp.MustCompile(`^.+$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerStorageReplicaExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		replicaId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageService(testAccProvider.Meta().(*session.Session))
+		foundReplica, err := service.Id(replicaId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundReplica.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerStorageReplicaConfig_basic = `
+resource "softlayer_file_storage" "fs_origin" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 20
+}
+
+resource "softlayer_storage_snapshot_schedule" "hourly" {
+    storage_id = "${softlayer_file_storage.fs_origin.id}"
+    schedule_type = "HOURLY"
+    retention_count = 24
+    minute = 15
+}
+
+resource "softlayer_storage_replica" "replica" {
+    storage_id = "${softlayer_storage_snapshot_schedule.hourly.storage_id}"
+    datacenter = "dal09"
+    schedule_type = "${softlayer_storage_snapshot_schedule.hourly.schedule_type}"
+}`
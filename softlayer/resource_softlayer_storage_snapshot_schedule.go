@@ -0,0 +1,314 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	storageScheduleMask = "id,active,volumeId,name,retentionCount,minute,hour,dayOfWeek,type[keyname]," +
+		"properties[value,type[keyname]]"
+)
+
+var storageScheduleTypes = []string{"HOURLY", "DAILY", "WEEKLY"}
+
+// Days of the week, in the order used by SoftLayer when the day of a weekly schedule is stored as a number
+var storageScheduleDaysOfWeek = []string{
+	"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY",
+}
+
+func resourceSoftLayerStorageSnapshotSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerStorageSnapshotScheduleCreate,
+		Read:     resourceSoftLayerStorageSnapshotScheduleRead,
+		Update:   resourceSoftLayerStorageSnapshotScheduleUpdate,
+		Delete:   resourceSoftLayerStorageSnapshotScheduleDelete,
+		Exists:   resourceSoftLayerStorageSnapshotScheduleExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"storage_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"schedule_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					scheduleType := v.(string)
+					for _, t := range storageScheduleTypes {
+						if scheduleType == t {
+							return
+						}
+					}
+					errs = append(errs, fmt.Errorf("%s is not one of the valid schedule types: %s",
+						scheduleType, strings.Join(storageScheduleTypes, ", ")))
+					return
+				},
+			},
+			"retention_count": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if v.(int) < 1 {
+						errs = append(errs, fmt.Errorf("retention_count should be greater than 0"))
+					}
+					return
+				},
+			},
+			"minute": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if minute := v.(int); minute < 0 || minute > 59 {
+						errs = append(errs, fmt.Errorf("minute should be between 0 and 59"))
+					}
+					return
+				},
+			},
+			"hour": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if hour := v.(int); hour < 0 || hour > 23 {
+						errs = append(errs, fmt.Errorf("hour should be between 0 and 23"))
+					}
+					return
+				},
+			},
+			"day_of_week": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					day := v.(string)
+					for _, d := range storageScheduleDaysOfWeek {
+						if day == d {
+							return
+						}
+					}
+					errs = append(errs, fmt.Errorf("%s is not one of the valid days of week: %s",
+						day, strings.Join(storageScheduleDaysOfWeek, ", ")))
+					return
+				},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerStorageSnapshotScheduleCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	storageId := d.Get("storage_id").(int)
+	scheduleType := d.Get("schedule_type").(string)
+
+	storage, err := services.GetNetworkStorageService(sess).Id(storageId).
+		Mask("id,snapshotCapacityGb").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage %d: %s", storageId, err)
+	}
+
+	// Snapshots are stored in the snapshot space of the volume. Schedules cannot be enabled without it.
+	if snapshotCapacity, _ := strconv.Atoi(sl.Get(storage.SnapshotCapacityGb, "0").(string)); snapshotCapacity == 0 {
+		return fmt.Errorf("Storage %d has no snapshot space. Set snapshot_capacity on the storage first", storageId)
+	}
+
+	err = enableStorageSnapshots(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating storage snapshot schedule: %s", err)
+	}
+
+	schedules, err := services.GetNetworkStorageService(sess).Id(storageId).
+		Filter(filter.Path("schedules.type.keyname").Eq("SNAPSHOT_" + scheduleType).Build()).
+		Mask("id").
+		GetSchedules()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage snapshot schedule: %s", err)
+	}
+
+	if len(schedules) == 0 {
+		return fmt.Errorf("Unable to find the %s snapshot schedule of storage %d", scheduleType, storageId)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *schedules[0].Id))
+	log.Printf("[INFO] Storage Snapshot Schedule ID: %s", d.Id())
+
+	return resourceSoftLayerStorageSnapshotScheduleRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotScheduleRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageScheduleService(sess)
+
+	scheduleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid storage snapshot schedule ID, must be an integer: %s", err)
+	}
+
+	schedule, err := service.Id(scheduleId).Mask(storageScheduleMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage snapshot schedule: %s", err)
+	}
+
+	d.Set("id", *schedule.Id)
+	d.Set("storage_id", sl.Get(schedule.VolumeId, d.Get("storage_id")))
+	d.Set("name", sl.Get(schedule.Name, ""))
+	d.Set("active", sl.Get(schedule.Active, 0).(int) == 1)
+
+	if schedule.Type != nil && schedule.Type.Keyname != nil {
+		d.Set("schedule_type", strings.TrimPrefix(*schedule.Type.Keyname, "SNAPSHOT_"))
+	}
+
+	// The schedule settings are stored as properties keyed by Network_Storage_Schedule_Property_Type.
+	// Fall back on the attributes of the schedule when a property is missing.
+	properties := map[string]string{
+		"MINUTE":         sl.Get(schedule.Minute, "").(string),
+		"HOUR":           sl.Get(schedule.Hour, "").(string),
+		"DAY_OF_WEEK":    sl.Get(schedule.DayOfWeek, "").(string),
+		"SNAPSHOT_LIMIT": sl.Get(schedule.RetentionCount, "").(string),
+	}
+	for _, property := range schedule.Properties {
+		if property.Type != nil && property.Type.Keyname != nil && property.Value != nil {
+			properties[*property.Type.Keyname] = *property.Value
+		}
+	}
+
+	if retentionCount, err := strconv.Atoi(properties["SNAPSHOT_LIMIT"]); err == nil {
+		d.Set("retention_count", retentionCount)
+	}
+
+	if minute, err := strconv.Atoi(properties["MINUTE"]); err == nil {
+		d.Set("minute", minute)
+	}
+
+	if hour, err := strconv.Atoi(properties["HOUR"]); err == nil {
+		d.Set("hour", hour)
+	}
+
+	if dayOfWeek := properties["DAY_OF_WEEK"]; dayOfWeek != "" && dayOfWeek != "*" {
+		if day, err := strconv.Atoi(dayOfWeek); err == nil && day >= 0 && day < len(storageScheduleDaysOfWeek) {
+			dayOfWeek = storageScheduleDaysOfWeek[day]
+		}
+		d.Set("day_of_week", strings.ToUpper(dayOfWeek))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotScheduleUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	// Enabling the snapshots of a volume again replaces the settings of its existing schedule
+	err := enableStorageSnapshots(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error updating storage snapshot schedule: %s", err)
+	}
+
+	return resourceSoftLayerStorageSnapshotScheduleRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotScheduleDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	storageId := d.Get("storage_id").(int)
+	scheduleType := d.Get("schedule_type").(string)
+
+	log.Printf("[INFO] Disabling %s snapshots of storage %d", scheduleType, storageId)
+	_, err := services.GetNetworkStorageService(sess).Id(storageId).DisableSnapshots(sl.String(scheduleType))
+	if err != nil {
+		return fmt.Errorf("Error deleting storage snapshot schedule: %s", err)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotScheduleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkStorageScheduleService(sess)
+
+	scheduleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid storage snapshot schedule ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(scheduleId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving storage snapshot schedule: %s", err)
+	}
+
+	return result.Id != nil && *result.Id == scheduleId, nil
+}
+
+func enableStorageSnapshots(d *schema.ResourceData, sess *session.Session) error {
+	storageId := d.Get("storage_id").(int)
+	scheduleType := d.Get("schedule_type").(string)
+	dayOfWeek := d.Get("day_of_week").(string)
+
+	if scheduleType == "WEEKLY" && dayOfWeek == "" {
+		return fmt.Errorf("day_of_week is required for WEEKLY snapshot schedules")
+	}
+
+	var hour *int
+	if scheduleType != "HOURLY" {
+		hour = sl.Int(d.Get("hour").(int))
+	}
+
+	var day *string
+	if scheduleType == "WEEKLY" {
+		day = sl.String(dayOfWeek)
+	}
+
+	log.Printf("[INFO] Enabling %s snapshots of storage %d", scheduleType, storageId)
+
+	success, err := services.GetNetworkStorageService(sess).Id(storageId).EnableSnapshots(
+		sl.String(scheduleType),
+		sl.Int(d.Get("retention_count").(int)),
+		sl.Int(d.Get("minute").(int)),
+		hour,
+		day,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful snapshot schedule update on storage %d", storageId)
+	}
+
+	return nil
+}
+
+// getStorageSnapshotSchedule returns the snapshot schedule of the given type of a storage volume.
+func getStorageSnapshotSchedule(storage datatypes.Network_Storage, scheduleType string) (datatypes.Network_Storage_Schedule, bool) {
+	for _, schedule := range storage.Schedules {
+		if schedule.Type != nil && sl.Get(schedule.Type.Keyname, "").(string) == "SNAPSHOT_"+scheduleType {
+			return schedule, true
+		}
+	}
+	return datatypes.Network_Storage_Schedule{}, false
+}
@@ -0,0 +1,131 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerStorageSnapshotSchedule_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageSnapshotScheduleConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerStorageSnapshotScheduleExists("softlayer_storage_snapshot_schedule.daily"),
+					resource.TestCheckResourceAttr(
+						"softlayer_file_storage.fs_snapshot", "snapshot_capacity", "20"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.daily", "schedule_type", "DAILY"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.daily", "retention_count", "7"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.daily", "minute", "30"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.daily", "hour", "2"),
+					testAccCheckSoftLayerStorageSnapshotScheduleExists("softlayer_storage_snapshot_schedule.weekly"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.weekly", "day_of_week", "SUNDAY"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageSnapshotScheduleConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.daily", "retention_count", "14"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.daily", "hour", "4"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.weekly", "day_of_week", "SATURDAY"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerStorageSnapshotScheduleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		scheduleId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageScheduleService(testAccProvider.Meta().(*session.Session))
+		foundSchedule, err := service.Id(scheduleId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundSchedule.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerStorageSnapshotScheduleConfig_basic = `
+resource "softlayer_file_storage" "fs_snapshot" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 20
+}
+
+resource "softlayer_storage_snapshot_schedule" "daily" {
+    storage_id = "${softlayer_file_storage.fs_snapshot.id}"
+    schedule_type = "DAILY"
+    retention_count = 7
+    minute = 30
+    hour = 2
+}
+
+resource "softlayer_storage_snapshot_schedule" "weekly" {
+    storage_id = "${softlayer_file_storage.fs_snapshot.id}"
+    schedule_type = "WEEKLY"
+    retention_count = 4
+    hour = 3
+    day_of_week = "SUNDAY"
+}`
+
+const testAccCheckSoftLayerStorageSnapshotScheduleConfig_updated = `
+resource "softlayer_file_storage" "fs_snapshot" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 20
+}
+
+resource "softlayer_storage_snapshot_schedule" "daily" {
+    storage_id = "${softlayer_file_storage.fs_snapshot.id}"
+    schedule_type = "DAILY"
+    retention_count = 14
+    minute = 30
+    hour = 4
+}
+
+resource "softlayer_storage_snapshot_schedule" "weekly" {
+    storage_id = "${softlayer_file_storage.fs_snapshot.id}"
+    schedule_type = "WEEKLY"
+    retention_count = 4
+    hour = 3
+    day_of_week = "SATURDAY"
+}`
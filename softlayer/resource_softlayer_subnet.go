@@ -0,0 +1,396 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	SubnetMask = "id,networkIdentifier,cidr,gateway,subnetType,addressSpace,version,note,networkVlanId," +
+		"endPointIpAddress[ipAddress],ipAddresses[ipAddress,isNetwork,isGateway,isBroadcast]"
+)
+
+func resourceSoftLayerSubnet() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerSubnetCreate,
+		Read:     resourceSoftLayerSubnetRead,
+		Update:   resourceSoftLayerSubnetUpdate,
+		Delete:   resourceSoftLayerSubnetDelete,
+		Exists:   resourceSoftLayerSubnetExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					subnetType := v.(string)
+					if subnetType != "Portable" && subnetType != "Static" {
+						errs = append(errs, errors.New(
+							"subnet type should be either 'Portable' or 'Static'"))
+					}
+					return
+				},
+			},
+			"private": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"ip_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  4,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					ipVersion := v.(int)
+					if ipVersion != 4 && ipVersion != 6 {
+						errs = append(errs, errors.New(
+							"ip version should be either 4 or 6"))
+					}
+					return
+				},
+			},
+			"capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"vlan_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"endpoint_ip"},
+			},
+			"endpoint_ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"vlan_id"},
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"network_identifier": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cidr": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"gateway": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"usable_ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerSubnetCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	productOrderContainer, err := buildSubnetProductOrderContainer(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating subnet: %s", err)
+	}
+
+	log.Println("[INFO] Creating subnet")
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of subnet: %s", err)
+	}
+
+	subnet, err := findSubnetByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of subnet: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *subnet.Id))
+
+	if d.Get("notes").(string) != "" {
+		_, err = services.GetNetworkSubnetService(sess).Id(*subnet.Id).EditNote(sl.String(d.Get("notes").(string)))
+		if err != nil {
+			return fmt.Errorf("Error updating subnet notes: %s", err)
+		}
+	}
+
+	return resourceSoftLayerSubnetRead(d, meta)
+}
+
+func resourceSoftLayerSubnetRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid subnet ID, must be an integer: %s", err)
+	}
+
+	subnet, err := service.Id(subnetId).Mask(SubnetMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving subnet: %s", err)
+	}
+
+	d.Set("id", *subnet.Id)
+	d.Set("network_identifier", sl.Get(subnet.NetworkIdentifier, ""))
+	d.Set("cidr", sl.Get(subnet.Cidr, 0))
+	d.Set("gateway", sl.Get(subnet.Gateway, ""))
+	d.Set("notes", sl.Get(subnet.Note, ""))
+	d.Set("ip_version", sl.Get(subnet.Version, 4))
+	d.Set("private", sl.Get(subnet.AddressSpace, "") == "PRIVATE")
+
+	if strings.HasPrefix(sl.Get(subnet.SubnetType, "").(string), "STATIC") {
+		d.Set("type", "Static")
+	} else {
+		d.Set("type", "Portable")
+	}
+
+	if subnet.EndPointIpAddress != nil {
+		d.Set("endpoint_ip", sl.Get(subnet.EndPointIpAddress.IpAddress, ""))
+	} else {
+		d.Set("vlan_id", sl.Get(subnet.NetworkVlanId, 0))
+	}
+
+	// IPv6 subnets are ordered as blocks of a fixed prefix length, so the capacity is kept as configured
+	if subnet.Cidr != nil && sl.Get(subnet.Version, 4).(int) == 4 {
+		d.Set("capacity", 1<<(uint)(32-*subnet.Cidr))
+	}
+
+	usableIps := make([]string, 0, len(subnet.IpAddresses))
+	for _, ip := range subnet.IpAddresses {
+		if sl.Get(ip.IsNetwork, false).(bool) ||
+			sl.Get(ip.IsGateway, false).(bool) ||
+			sl.Get(ip.IsBroadcast, false).(bool) {
+			continue
+		}
+		usableIps = append(usableIps, sl.Get(ip.IpAddress, "").(string))
+	}
+	d.Set("usable_ips", usableIps)
+
+	return nil
+}
+
+func resourceSoftLayerSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid subnet ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("notes") {
+		_, err = service.Id(subnetId).EditNote(sl.String(d.Get("notes").(string)))
+		if err != nil {
+			return fmt.Errorf("Error updating subnet notes: %s", err)
+		}
+	}
+
+	return resourceSoftLayerSubnetRead(d, meta)
+}
+
+func resourceSoftLayerSubnetDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid subnet ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(subnetId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting subnet: %s", err)
+	}
+
+	// Subnets without a billing item, like the primary subnets of VLANs, are managed by SoftLayer.
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+
+	return err
+}
+
+func resourceSoftLayerSubnetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkSubnetService(sess)
+
+	subnetId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid subnet ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(subnetId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving subnet: %s", err)
+	}
+	return result.Id != nil && *result.Id == subnetId, nil
+}
+
+func findSubnetByOrderId(sess *session.Session, orderId int) (datatypes.Network_Subnet, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			subnets, err := services.GetAccountService(sess).
+				Filter(filter.Path("subnets.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id,activeTransaction[id]").
+				GetSubnets()
+			if err != nil {
+				return datatypes.Network_Subnet{}, "", err
+			}
+
+			if len(subnets) == 1 && subnets[0].ActiveTransaction == nil {
+				return subnets[0], "complete", nil
+			} else if len(subnets) <= 1 {
+				return nil, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one subnet for order %d, found %d", orderId, len(subnets))
+			}
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Subnet{}, err
+	}
+
+	var result, ok = pendingResult.(datatypes.Network_Subnet)
+
+	if ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Subnet{},
+		fmt.Errorf("Cannot find subnet with order id '%d'", orderId)
+}
+
+// getSubnetItemKeyName returns the key name of the product item for a subnet, such as
+// 8_PORTABLE_PRIVATE_IP_ADDRESSES or 64_BLOCK_STATIC_PUBLIC_IPV6_ADDRESSES.
+func getSubnetItemKeyName(subnetType string, private bool, ipVersion, capacity int) string {
+	if ipVersion == 6 {
+		return fmt.Sprintf("%d_BLOCK_%s_PUBLIC_IPV6_ADDRESSES", capacity, strings.ToUpper(subnetType))
+	}
+
+	addressSpace := "PUBLIC"
+	if private {
+		addressSpace = "PRIVATE"
+	}
+	return fmt.Sprintf("%d_%s_%s_IP_ADDRESSES", capacity, strings.ToUpper(subnetType), addressSpace)
+}
+
+func buildSubnetProductOrderContainer(d *schema.ResourceData, sess *session.Session) (
+	*datatypes.Container_Product_Order_Network_Subnet, error) {
+	subnetType := d.Get("type").(string)
+	private := d.Get("private").(bool)
+	ipVersion := d.Get("ip_version").(int)
+	vlanId := d.Get("vlan_id").(int)
+	endpointIp := d.Get("endpoint_ip").(string)
+
+	if subnetType == "Portable" && vlanId == 0 {
+		return nil, errors.New("vlan_id is required for a portable subnet")
+	}
+
+	if subnetType == "Static" && endpointIp == "" {
+		return nil, errors.New("endpoint_ip is required for a static subnet")
+	}
+
+	if private && (subnetType == "Static" || ipVersion == 6) {
+		return nil, errors.New("only portable IPv4 subnets can be private")
+	}
+
+	// 1. Get a package
+	pkg, err := product.GetPackageByType(sess, AdditionalServicesPackageType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Get all prices for the package
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Find the subnet price
+	keyName := getSubnetItemKeyName(subnetType, private, ipVersion, d.Get("capacity").(int))
+
+	var subnetItem *datatypes.Product_Item
+	for i, item := range productItems {
+		if item.KeyName != nil && *item.KeyName == keyName && len(item.Prices) > 0 {
+			subnetItem = &productItems[i]
+			break
+		}
+	}
+
+	if subnetItem == nil {
+		return nil, fmt.Errorf("No product items matching %s could be found", keyName)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Subnet{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: subnetItem.Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+	}
+
+	// 4. Route the subnet to a VLAN or to an IP address
+	if subnetType == "Portable" {
+		productOrderContainer.EndPointVlanId = sl.Int(vlanId)
+	} else {
+		ipAddress, err := services.GetNetworkSubnetIpAddressService(sess).GetByIpAddress(sl.String(endpointIp))
+		if err != nil {
+			return nil, fmt.Errorf("Error looking up endpoint ip %s: %s", endpointIp, err)
+		}
+		if ipAddress.Id == nil {
+			return nil, fmt.Errorf("Unable to locate the endpoint ip %s", endpointIp)
+		}
+		productOrderContainer.EndPointIpAddressId = ipAddress.Id
+	}
+
+	return &productOrderContainer, nil
+}
@@ -0,0 +1,155 @@
+package softlayer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerSubnet_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerSubnetConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSubnetExists("softlayer_subnet.portable-subnet"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable-subnet", "type", "Portable"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable-subnet", "private", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable-subnet", "capacity", "4"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable-subnet", "cidr", "30"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable-subnet", "notes", "portable_subnet"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable-subnet", "usable_ips.#", "1"),
+					resource.TestMatchResourceAttr("softlayer_subnet.portable-subnet", "gateway",
+						regexp.MustCompile(`^(([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))\.){3}([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))$`)),
+					testAccCheckSoftLayerResources("softlayer_subnet.portable-subnet", "vlan_id",
+						"softlayer_virtual_guest.subnetvm1", "private_vlan_id"),
+
+					testAccCheckSoftLayerSubnetExists("softlayer_subnet.static-subnet"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.static-subnet", "type", "Static"),
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.static-subnet", "cidr", "30"),
+					testAccCheckSoftLayerResources("softlayer_subnet.static-subnet", "endpoint_ip",
+						"softlayer_virtual_guest.subnetvm1", "ipv4_address"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerSubnetConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_subnet.portable-subnet", "notes", "updated_portable_subnet"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerSubnetExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		subnetId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkSubnetService(testAccProvider.Meta().(*session.Session))
+		foundSubnet, err := service.Id(subnetId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundSubnet.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerSubnetConfig_basic = `
+resource "softlayer_virtual_guest" "subnetvm1" {
+    name = "subnetvm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_subnet" "portable-subnet" {
+    type = "Portable"
+    private = true
+    ip_version = 4
+    capacity = 4
+    vlan_id = "${softlayer_virtual_guest.subnetvm1.private_vlan_id}"
+    notes = "portable_subnet"
+}
+
+resource "softlayer_subnet" "static-subnet" {
+    type = "Static"
+    private = false
+    ip_version = 4
+    capacity = 4
+    endpoint_ip="${softlayer_virtual_guest.subnetvm1.ipv4_address}"
+    notes = "static_subnet"
+}`
+
+const testAccCheckSoftLayerSubnetConfig_updated = `
+resource "softlayer_virtual_guest" "subnetvm1" {
+    name = "subnetvm1"
+    domain = "example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_subnet" "portable-subnet" {
+    type = "Portable"
+    private = true
+    ip_version = 4
+    capacity = 4
+    vlan_id = "${softlayer_virtual_guest.subnetvm1.private_vlan_id}"
+    notes = "updated_portable_subnet"
+}
+
+resource "softlayer_subnet" "static-subnet" {
+    type = "Static"
+    private = false
+    ip_version = 4
+    capacity = 4
+    endpoint_ip="${softlayer_virtual_guest.subnetvm1.ipv4_address}"
+    notes = "static_subnet"
+}`
@@ -109,6 +109,30 @@ func resourceSoftLayerUser() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"vpn_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"pptp_vpn_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"vpn_manual_config": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"vpn_subnet_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set: func(v interface{}) int {
+					return v.(int)
+				},
+			},
 		},
 	}
 }
@@ -166,6 +190,17 @@ func resourceSoftLayerUserCreate(d *schema.ResourceData, meta interface{}) error
 		UserStatusId: &userStatusID,
 	}
 
+	// The VPN settings which are not configured keep the defaults of the account
+	if isComputedSettingConfigured(d, "vpn_enabled") {
+		opts.SslVpnAllowedFlag = sl.Bool(d.Get("vpn_enabled").(bool))
+	}
+	if isComputedSettingConfigured(d, "pptp_vpn_enabled") {
+		opts.PptpVpnAllowedFlag = sl.Bool(d.Get("pptp_vpn_enabled").(bool))
+	}
+	if isComputedSettingConfigured(d, "vpn_manual_config") {
+		opts.VpnManualConfig = sl.Bool(d.Get("vpn_manual_config").(bool))
+	}
+
 	if address2, ok := d.GetOk("address2"); ok {
 		opts.Address2 = sl.String(address2.(string))
 	}
@@ -213,6 +248,13 @@ func resourceSoftLayerUserCreate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if subnetIds := d.Get("vpn_subnet_ids").(*schema.Set).List(); len(subnetIds) > 0 {
+		err = updateUserVpnSubnets(sess, *res.Id, subnetIds, nil)
+		if err != nil {
+			return fmt.Errorf("Error setting VPN subnets for SoftLayer User: %s", err)
+		}
+	}
+
 	return resourceSoftLayerUserRead(d, meta)
 }
 
@@ -237,6 +279,10 @@ func resourceSoftLayerUserRead(d *schema.ResourceData, meta interface{}) error {
 		"userStatus.keyName",
 		"permissions.keyName",
 		"apiAuthenticationKeys.authenticationKey",
+		"sslVpnAllowedFlag",
+		"pptpVpnAllowedFlag",
+		"vpnManualConfig",
+		"overrides.subnetId",
 	}, ";")
 
 	sluserObj, err := service.Id(userID).Mask(mask).GetObject()
@@ -280,6 +326,18 @@ func resourceSoftLayerUserRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("has_api_key", false)
 	}
 
+	d.Set("vpn_enabled", sl.Get(sluserObj.SslVpnAllowedFlag, false))
+	d.Set("pptp_vpn_enabled", sl.Get(sluserObj.PptpVpnAllowedFlag, false))
+	d.Set("vpn_manual_config", sl.Get(sluserObj.VpnManualConfig, false))
+
+	vpnSubnetIds := make([]interface{}, 0, len(sluserObj.Overrides))
+	for _, override := range sluserObj.Overrides {
+		if override.SubnetId != nil {
+			vpnSubnetIds = append(vpnSubnetIds, *override.SubnetId)
+		}
+	}
+	d.Set("vpn_subnet_ids", vpnSubnetIds)
+
 	return nil
 }
 
@@ -355,6 +413,15 @@ func resourceSoftLayerUserUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 		userObj.UserStatusId = &userStatusID
 	}
+	if d.HasChange("vpn_enabled") {
+		userObj.SslVpnAllowedFlag = sl.Bool(d.Get("vpn_enabled").(bool))
+	}
+	if d.HasChange("pptp_vpn_enabled") {
+		userObj.PptpVpnAllowedFlag = sl.Bool(d.Get("pptp_vpn_enabled").(bool))
+	}
+	if d.HasChange("vpn_manual_config") {
+		userObj.VpnManualConfig = sl.Bool(d.Get("vpn_manual_config").(bool))
+	}
 
 	_, err = service.EditObject(&userObj)
 	if err != nil {
@@ -425,6 +492,20 @@ func resourceSoftLayerUserUpdate(d *schema.ResourceData, meta interface{}) error
 			d.Set("api_key", nil)
 		}
 	}
+
+	if d.HasChange("vpn_subnet_ids") {
+		add, remove := getSetChanges(d, "vpn_subnet_ids")
+		err = updateUserVpnSubnets(sess, sluid, add, remove)
+		if err != nil {
+			return fmt.Errorf("Error received while updating VPN subnets of softlayer_user: %s", err)
+		}
+	} else if d.HasChange("vpn_enabled") || d.HasChange("pptp_vpn_enabled") || d.HasChange("vpn_manual_config") {
+		// The VPN settings are only pushed to the VPN service when the user is updated
+		_, err = service.UpdateVpnUser()
+		if err != nil {
+			return fmt.Errorf("Error received while updating VPN access of softlayer_user: %s", err)
+		}
+	}
 	return nil
 }
 
@@ -458,6 +539,51 @@ func resourceSoftLayerUserExists(d *schema.ResourceData, meta interface{}) (bool
 	return result.Id != nil && *result.Id == id && err == nil, nil
 }
 
+// updateUserVpnSubnets grants and revokes the VPN access of a user to subnets, and pushes the changes
+// to the VPN service. The subnets are only enforced for users with a manual VPN configuration.
+func updateUserVpnSubnets(sess *session.Session, userID int, add []interface{}, remove []interface{}) error {
+	if len(remove) > 0 {
+		overrides, err := services.GetUserCustomerService(sess).Id(userID).Mask("id,subnetId").GetOverrides()
+		if err != nil {
+			return err
+		}
+
+		removed := make([]datatypes.Network_Service_Vpn_Overrides, 0, len(remove))
+		for _, override := range overrides {
+			for _, subnetID := range remove {
+				if override.SubnetId != nil && *override.SubnetId == subnetID.(int) {
+					removed = append(removed, datatypes.Network_Service_Vpn_Overrides{Id: override.Id})
+				}
+			}
+		}
+
+		if len(removed) > 0 {
+			_, err = services.GetNetworkServiceVpnOverridesService(sess).DeleteObjects(removed)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(add) > 0 {
+		added := make([]datatypes.Network_Service_Vpn_Overrides, 0, len(add))
+		for _, subnetID := range add {
+			added = append(added, datatypes.Network_Service_Vpn_Overrides{
+				UserId:   sl.Int(userID),
+				SubnetId: sl.Int(subnetID.(int)),
+			})
+		}
+
+		_, err := services.GetNetworkServiceVpnOverridesService(sess).CreateObjects(added)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := services.GetUserCustomerService(sess).Id(userID).UpdateVpnUser()
+	return err
+}
+
 func getTimezoneIDByName(sess *session.Session, shortName string) (int, error) {
 	zones, err := services.GetLocaleTimezoneService(sess).
 		Mask("id,shortName").
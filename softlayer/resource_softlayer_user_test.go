@@ -59,6 +59,10 @@ func TestAccSoftLayerUser_Basic(t *testing.T) {
 						"softlayer_user.testuser", "has_api_key", "true"),
 					resource.TestMatchResourceAttr(
 						"softlayer_user.testuser", "api_key", apiKeyRegexp),
+					resource.TestCheckResourceAttr(
+						"softlayer_user.testuser", "vpn_enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_user.testuser", "vpn_manual_config", "false"),
 				),
 			},
 
@@ -97,6 +101,12 @@ func TestAccSoftLayerUser_Basic(t *testing.T) {
 						"softlayer_user.testuser", "has_api_key", "false"),
 					resource.TestCheckResourceAttr(
 						"softlayer_user.testuser", "api_key", ""),
+					resource.TestCheckResourceAttr(
+						"softlayer_user.testuser", "vpn_enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_user.testuser", "pptp_vpn_enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_user.testuser", "vpn_manual_config", "true"),
 				),
 			},
 		},
@@ -175,6 +185,7 @@ resource "softlayer_user" "testuser" {
         "ACCESS_ALL_GUEST"
     ]
     has_api_key = true
+    vpn_enabled = true
 }`, testAccRandomUserName, testAccUserPassword)
 
 var testAccCheckSoftLayerUserConfig_updated = fmt.Sprintf(`
@@ -198,6 +209,9 @@ resource "softlayer_user" "testuser" {
         "TICKET_EDIT"
     ]
     has_api_key = false
+    vpn_enabled = true
+    pptp_vpn_enabled = true
+    vpn_manual_config = true
 }`, testAccRandomUserName, testAccUserPassword)
 
 var testAccRandomUserName = resource.UniqueId()
@@ -0,0 +1,271 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	VlanFirewallMask = "id,primaryIpAddress,fullyQualifiedDomainName,datacenter[name]," +
+		"managementCredentials[username,password],networkVlan[id,highAvailabilityFirewallFlag]"
+)
+
+// Key names of the dedicated firewall items, by firewall type and high availability
+var vlanFirewallItemKeyNames = map[string]map[bool]string{
+	"HARDWARE_FIREWALL_DEDICATED": {
+		false: "HARDWARE_FIREWALL_DEDICATED",
+		true:  "HARDWARE_FIREWALL_HIGH_AVAILABILITY",
+	},
+	"FORTIGATE_SECURITY_APPLIANCE": {
+		false: "FORTIGATE_SECURITY_APPLIANCE",
+		true:  "FORTIGATE_SECURITY_APPLIANCE_HIGH_AVAILABILITY",
+	},
+}
+
+func resourceSoftLayerVlanFirewall() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerVlanFirewallCreate,
+		Read:     resourceSoftLayerVlanFirewallRead,
+		Delete:   resourceSoftLayerVlanFirewallDelete,
+		Exists:   resourceSoftLayerVlanFirewallExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"firewall_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "HARDWARE_FIREWALL_DEDICATED",
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errs []error) {
+					if _, ok := vlanFirewallItemKeyNames[v.(string)]; !ok {
+						errs = append(errs, errors.New(
+							"firewall type should be either 'HARDWARE_FIREWALL_DEDICATED' or 'FORTIGATE_SECURITY_APPLIANCE'"))
+					}
+					return
+				},
+			},
+			"ha_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+			"primary_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fqdn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"datacenter": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerVlanFirewallCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vlanId := d.Get("vlan_id").(int)
+	keyName := vlanFirewallItemKeyNames[d.Get("firewall_type").(string)][d.Get("ha_enabled").(bool)]
+
+	pkg, err := product.GetPackageByType(sess, AdditionalServicesPackageType)
+	if err != nil {
+		return fmt.Errorf("Error creating vlan firewall: %s", err)
+	}
+
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	if err != nil {
+		return fmt.Errorf("Error creating vlan firewall: %s", err)
+	}
+
+	var firewallItem *datatypes.Product_Item
+	for i, item := range productItems {
+		if item.KeyName != nil && *item.KeyName == keyName && len(item.Prices) > 0 {
+			firewallItem = &productItems[i]
+			break
+		}
+	}
+
+	if firewallItem == nil {
+		return fmt.Errorf("Error creating vlan firewall: No product items matching %s could be found", keyName)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Protection_Firewall_Dedicated{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Prices: []datatypes.Product_Item_Price{
+				{
+					Id: firewallItem.Prices[0].Id,
+				},
+			},
+			Quantity: sl.Int(1),
+		},
+		VlanId: sl.Int(vlanId),
+	}
+
+	log.Printf("[INFO] Creating vlan firewall for vlan %d", vlanId)
+
+	_, err = services.GetProductOrderService(sess).PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of vlan firewall: %s", err)
+	}
+
+	firewall, err := findVlanFirewallByVlanId(sess, vlanId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of vlan firewall: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *firewall.Id))
+
+	return resourceSoftLayerVlanFirewallRead(d, meta)
+}
+
+func resourceSoftLayerVlanFirewallRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkVlanFirewallService(sess)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid vlan firewall ID, must be an integer: %s", err)
+	}
+
+	firewall, err := service.Id(firewallId).Mask(VlanFirewallMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving vlan firewall: %s", err)
+	}
+
+	d.Set("id", *firewall.Id)
+	d.Set("primary_ip", sl.Get(firewall.PrimaryIpAddress, ""))
+	d.Set("fqdn", sl.Get(firewall.FullyQualifiedDomainName, ""))
+
+	if firewall.Datacenter != nil {
+		d.Set("datacenter", sl.Get(firewall.Datacenter.Name, ""))
+	}
+
+	if firewall.NetworkVlan != nil {
+		d.Set("vlan_id", sl.Get(firewall.NetworkVlan.Id, 0))
+		d.Set("ha_enabled", sl.Get(firewall.NetworkVlan.HighAvailabilityFirewallFlag, false))
+	}
+
+	if firewall.ManagementCredentials != nil {
+		d.Set("username", sl.Get(firewall.ManagementCredentials.Username, ""))
+		d.Set("password", sl.Get(firewall.ManagementCredentials.Password, ""))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerVlanFirewallDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkVlanFirewallService(sess)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid vlan firewall ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := service.Id(firewallId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting vlan firewall: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return nil
+	}
+
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+
+	return err
+}
+
+func resourceSoftLayerVlanFirewallExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkVlanFirewallService(sess)
+
+	firewallId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid vlan firewall ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(firewallId).Mask("id").GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving vlan firewall: %s", err)
+	}
+	return result.Id != nil && *result.Id == firewallId, nil
+}
+
+// findVlanFirewallByVlanId waits for the dedicated firewall of a vlan to be provisioned
+func findVlanFirewallByVlanId(sess *session.Session, vlanId int) (datatypes.Network_Vlan_Firewall, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			vlan, err := services.GetNetworkVlanService(sess).
+				Id(vlanId).
+				Mask("id,networkVlanFirewall[id,primaryIpAddress]").
+				GetObject()
+			if err != nil {
+				return datatypes.Network_Vlan_Firewall{}, "", err
+			}
+
+			if vlan.NetworkVlanFirewall != nil && vlan.NetworkVlanFirewall.PrimaryIpAddress != nil {
+				return *vlan.NetworkVlanFirewall, "complete", nil
+			}
+			return nil, "pending", nil
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Vlan_Firewall{}, err
+	}
+
+	var result, ok = pendingResult.(datatypes.Network_Vlan_Firewall)
+
+	if ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Vlan_Firewall{},
+		fmt.Errorf("Cannot find vlan firewall of vlan '%d'", vlanId)
+}
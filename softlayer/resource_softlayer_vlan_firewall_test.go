@@ -0,0 +1,77 @@
+package softlayer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerVlanFirewall_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerVlanFirewallConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerVlanFirewallExists("softlayer_vlan_firewall.test_firewall"),
+					testAccCheckSoftLayerResources("softlayer_vlan_firewall.test_firewall", "vlan_id",
+						"softlayer_vlan.test_firewall_vlan", "id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan_firewall.test_firewall", "ha_enabled", "false"),
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan_firewall.test_firewall", "datacenter", "dal06"),
+					resource.TestMatchResourceAttr("softlayer_vlan_firewall.test_firewall", "primary_ip",
+						regexp.MustCompile(`^(([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))\.){3}([01]?[0-9]?[0-9]|2([0-4][0-9]|5[0-5]))$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerVlanFirewallExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		firewallId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkVlanFirewallService(testAccProvider.Meta().(*session.Session))
+		foundFirewall, err := service.Id(firewallId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundFirewall.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerVlanFirewallConfig_basic = `
+resource "softlayer_vlan" "test_firewall_vlan" {
+   name = "test_firewall_vlan"
+   datacenter = "dal06"
+   type = "PUBLIC"
+   subnet_size = 8
+}
+
+resource "softlayer_vlan_firewall" "test_firewall" {
+   vlan_id = "${softlayer_vlan.test_firewall_vlan.id}"
+}`